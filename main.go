@@ -16,13 +16,27 @@ func main() {
 	// -j <jar> - Jar to read
 	// -o <file> - File to write
 	// -v - Verbose output
+	//
+	// Subcommands
+	// collisions -i <dir> - Report command and permission collisions between plugins
+
+	if len(os.Args) > 1 && os.Args[1] == "collisions" {
+		collisions(os.Args[2:])
+		return
+	}
 
 	file := flag.String("f", "", "File to read")
 	inputDir := flag.String("i", "", "Directory to read")
+	lenient := flag.Bool("lenient", false, "Tolerate malformed JSON descriptors")
 	// outputDir := flag.String("o", "", "Directory to dump metadata to")
 
 	flag.Parse()
 
+	opts := make([]mcmodmeta.Option, 0)
+	if *lenient {
+		opts = append(opts, mcmodmeta.WithLenientJSON())
+	}
+
 	if *inputDir != "" {
 		entries, err := os.ReadDir(*inputDir)
 		if err != nil {
@@ -32,22 +46,73 @@ func main() {
 		for _, e := range entries {
 			if !e.IsDir() && strings.Contains(e.Name(), ".jar") && strings.Contains(e.Name(), "fabric") {
 				fmt.Println(e.Name())
-				mods, err := mcmodmeta.ReadJarFile(*inputDir + "/" + e.Name())
+				jar, err := mcmodmeta.ReadJarFile(*inputDir+"/"+e.Name(), opts...)
 				if err != nil {
 					fmt.Println(err)
 				} else {
-					fmt.Println(mods)
+					printJar(jar)
 				}
 			}
 		}
 	}
 
 	if *file != "" {
-		mods, err := mcmodmeta.ReadJarFile(*file)
+		jar, err := mcmodmeta.ReadJarFile(*file, opts...)
 		if err != nil {
 			fmt.Println(err)
 		} else {
-			fmt.Println(mods)
+			printJar(jar)
+		}
+	}
+}
+
+func printJar(jar *mcmodmeta.JarMetadata) {
+	for _, platform := range jar.Platforms() {
+		fmt.Printf("  %s:\n", platform)
+		for _, mod := range jar.ModsFor(platform) {
+			fmt.Printf("    %s (%s) %s - %s\n", mod.ID, mod.Name, mod.Version, mod.Source)
+		}
+	}
+	if java := jar.Java.Minimum(); java > 0 {
+		fmt.Printf("  requires Java %d\n", java)
+	}
+	for _, err := range jar.Errors {
+		fmt.Printf("  error: %v\n", err)
+	}
+	for _, warning := range jar.Warnings {
+		fmt.Printf("  warning: %s\n", warning)
+	}
+	for _, child := range jar.Children {
+		fmt.Printf("  nested %s\n", child.Path)
+		printJar(child)
+	}
+}
+
+func collisions(args []string) {
+	flags := flag.NewFlagSet("collisions", flag.ExitOnError)
+	inputDir := flags.String("i", ".", "Directory of plugins to check")
+	flags.Parse(args)
+
+	jars, err := mcmodmeta.ReadJarDir(*inputDir)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	report := mcmodmeta.FindCollisions(jars)
+	for _, collision := range report.Commands {
+		fmt.Printf("command /%s:\n", collision.Label)
+		for _, registration := range collision.Registrations {
+			if registration.Alias {
+				fmt.Printf("  %s (alias of /%s) - %s\n", registration.Plugin, registration.Command, registration.Jar)
+			} else {
+				fmt.Printf("  %s - %s\n", registration.Plugin, registration.Jar)
+			}
+		}
+	}
+	for _, conflict := range report.Permissions {
+		fmt.Printf("permission %s:\n", conflict.Permission)
+		for _, declaration := range conflict.Declarations {
+			fmt.Printf("  %s defaults to %s - %s\n", declaration.Plugin, declaration.Default, declaration.Jar)
 		}
 	}
 }
@@ -0,0 +1,335 @@
+package mcmodmeta
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+)
+
+type (
+	// ClassFile is the part of a compiled Java class this library reads
+	ClassFile struct {
+		MajorVersion uint16
+		MinorVersion uint16
+		Name         string       // Internal name, e.g. net/example/ExampleMod
+		Annotations  []Annotation // Runtime visible annotations on the class itself
+	}
+
+	// Annotation is a Java annotation and its element values. Values are string, bool, int32, int64,
+	// float32, float64, AnnotationEnum, AnnotationClass, Annotation or []any for arrays.
+	Annotation struct {
+		Type   string // Type descriptor, e.g. Lnet/minecraftforge/fml/common/Mod;
+		Values map[string]any
+	}
+
+	// AnnotationEnum is an enum constant used as an annotation value
+	AnnotationEnum struct {
+		Type  string // Type descriptor of the enum
+		Const string
+	}
+
+	// AnnotationClass is a class literal used as an annotation value, as a return descriptor such as Ljava/lang/String;
+	AnnotationClass string
+
+	// classReader reads big-endian values from a class file, remembering the first error
+	classReader struct {
+		data []byte
+		pos  int
+		err  error
+	}
+
+	// constantPoolEntry is a single constant pool entry, only the parts annotations need
+	constantPoolEntry struct {
+		tag   byte
+		utf8  string
+		index uint16 // Class and String entries point at a Utf8 entry
+		bits  uint64 // Integer, Float, Long and Double entries
+	}
+)
+
+// Constant pool tags, see the JVM specification section 4.4
+const (
+	constantUtf8               = 1
+	constantInteger            = 3
+	constantFloat              = 4
+	constantLong               = 5
+	constantDouble             = 6
+	constantClass              = 7
+	constantString             = 8
+	constantFieldref           = 9
+	constantMethodref          = 10
+	constantInterfaceMethodref = 11
+	constantNameAndType        = 12
+	constantMethodHandle       = 15
+	constantMethodType         = 16
+	constantDynamic            = 17
+	constantInvokeDynamic      = 18
+	constantModule             = 19
+	constantPackage            = 20
+)
+
+var (
+	errTruncatedClass = errors.New("truncated class file")
+	errNotClass       = errors.New("not a class file")
+)
+
+// ParseClassFile reads the version, name and class annotations of a compiled Java class
+func ParseClassFile(data []byte) (*ClassFile, error) {
+	r := &classReader{data: data}
+	if r.u4() != 0xCAFEBABE {
+		return nil, errNotClass
+	}
+	class := &ClassFile{MinorVersion: r.u2(), MajorVersion: r.u2(), Annotations: make([]Annotation, 0)}
+
+	pool, err := r.constantPool()
+	if err != nil {
+		return nil, err
+	}
+
+	r.u2() // access flags
+	thisClass := r.u2()
+	r.u2() // super class
+	r.skip(int(r.u2()) * 2)
+	for _, members := range []string{"fields", "methods"} {
+		count := int(r.u2())
+		for i := 0; i < count && r.err == nil; i++ {
+			r.skip(6)
+			r.skipAttributes()
+		}
+		if r.err != nil {
+			return nil, fmt.Errorf("reading %s: %w", members, r.err)
+		}
+	}
+
+	attributes := int(r.u2())
+	for i := 0; i < attributes && r.err == nil; i++ {
+		name := pool.utf8(r.u2())
+		length := int(r.u4())
+		end := r.pos + length
+		if name == "RuntimeVisibleAnnotations" {
+			count := int(r.u2())
+			for j := 0; j < count && r.err == nil; j++ {
+				annotation, err := r.annotation(pool)
+				if err != nil {
+					return nil, err
+				}
+				class.Annotations = append(class.Annotations, annotation)
+			}
+		}
+		r.pos = end
+	}
+	if r.err != nil || r.pos > len(data) {
+		return nil, errTruncatedClass
+	}
+
+	if thisClass < uint16(len(pool)) {
+		class.Name = pool.utf8(pool[thisClass].index)
+	}
+	return class, nil
+}
+
+// Annotation returns the class annotation of the given type descriptor, if present
+func (c *ClassFile) Annotation(descriptor string) (Annotation, bool) {
+	for _, annotation := range c.Annotations {
+		if annotation.Type == descriptor {
+			return annotation, true
+		}
+	}
+	return Annotation{}, false
+}
+
+// JavaVersion returns the Java release the class was compiled for, e.g. 8 for major version 52
+func (c *ClassFile) JavaVersion() int {
+	return javaRelease(c.MajorVersion)
+}
+
+// javaRelease converts a class file major version to the Java release that introduced it
+func javaRelease(major uint16) int {
+	return int(major) - 44
+}
+
+// readClassVersion reads the major version from the start of a class file, without reading the rest of it
+func readClassVersion(r io.Reader) (uint16, error) {
+	header := make([]byte, 8)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return 0, errTruncatedClass
+	}
+	if binary.BigEndian.Uint32(header) != 0xCAFEBABE {
+		return 0, errNotClass
+	}
+	return binary.BigEndian.Uint16(header[6:]), nil
+}
+
+// String returns a string value, or "" if it is missing or not a string
+func (a Annotation) String(name string) string {
+	value, _ := a.Values[name].(string)
+	return value
+}
+
+// Bool returns a boolean value, or false if it is missing or not a boolean
+func (a Annotation) Bool(name string) bool {
+	value, _ := a.Values[name].(bool)
+	return value
+}
+
+func (r *classReader) u1() byte {
+	if r.err != nil || r.pos+1 > len(r.data) {
+		r.err = errTruncatedClass
+		return 0
+	}
+	r.pos++
+	return r.data[r.pos-1]
+}
+
+func (r *classReader) u2() uint16 {
+	if r.err != nil || r.pos+2 > len(r.data) {
+		r.err = errTruncatedClass
+		return 0
+	}
+	r.pos += 2
+	return binary.BigEndian.Uint16(r.data[r.pos-2:])
+}
+
+func (r *classReader) u4() uint32 {
+	if r.err != nil || r.pos+4 > len(r.data) {
+		r.err = errTruncatedClass
+		return 0
+	}
+	r.pos += 4
+	return binary.BigEndian.Uint32(r.data[r.pos-4:])
+}
+
+func (r *classReader) skip(n int) {
+	if r.err != nil || n < 0 || r.pos+n > len(r.data) {
+		r.err = errTruncatedClass
+		return
+	}
+	r.pos += n
+}
+
+// skipAttributes skips an attribute table
+func (r *classReader) skipAttributes() {
+	count := int(r.u2())
+	for i := 0; i < count && r.err == nil; i++ {
+		r.u2()
+		r.skip(int(r.u4()))
+	}
+}
+
+// constantPool reads the constant pool, which is indexed from 1 with longs and doubles taking two slots
+func (r *classReader) constantPool() (constantPool, error) {
+	count := int(r.u2())
+	pool := make(constantPool, count)
+	for i := 1; i < count && r.err == nil; i++ {
+		entry := constantPoolEntry{tag: r.u1()}
+		switch entry.tag {
+		case constantUtf8:
+			length := int(r.u2())
+			start := r.pos
+			r.skip(length)
+			if r.err == nil {
+				entry.utf8 = string(r.data[start : start+length])
+			}
+		case constantInteger, constantFloat:
+			entry.bits = uint64(r.u4())
+		case constantLong, constantDouble:
+			entry.bits = uint64(r.u4())<<32 | uint64(r.u4())
+		case constantClass, constantString, constantMethodType, constantModule, constantPackage:
+			entry.index = r.u2()
+		case constantFieldref, constantMethodref, constantInterfaceMethodref, constantNameAndType, constantDynamic, constantInvokeDynamic:
+			r.skip(4)
+		case constantMethodHandle:
+			r.skip(3)
+		default:
+			if r.err == nil {
+				return nil, fmt.Errorf("unknown constant pool tag %d at entry %d", entry.tag, i)
+			}
+		}
+		pool[i] = entry
+		if entry.tag == constantLong || entry.tag == constantDouble {
+			i++
+		}
+	}
+	if r.err != nil {
+		return nil, fmt.Errorf("reading constant pool: %w", r.err)
+	}
+	return pool, nil
+}
+
+// constantPool is a class's constant pool, where index 0 is unused
+type constantPool []constantPoolEntry
+
+// utf8 returns the Utf8 entry at index, or "" if there is none
+func (pool constantPool) utf8(index uint16) string {
+	if int(index) >= len(pool) || pool[index].tag != constantUtf8 {
+		return ""
+	}
+	return pool[index].utf8
+}
+
+// annotation reads an annotation structure
+func (r *classReader) annotation(pool constantPool) (Annotation, error) {
+	annotation := Annotation{Type: pool.utf8(r.u2()), Values: map[string]any{}}
+	count := int(r.u2())
+	for i := 0; i < count && r.err == nil; i++ {
+		name := pool.utf8(r.u2())
+		value, err := r.elementValue(pool)
+		if err != nil {
+			return annotation, err
+		}
+		annotation.Values[name] = value
+	}
+	if r.err != nil {
+		return annotation, fmt.Errorf("reading annotation %s: %w", annotation.Type, r.err)
+	}
+	return annotation, nil
+}
+
+// elementValue reads a single annotation element value
+func (r *classReader) elementValue(pool constantPool) (any, error) {
+	tag := r.u1()
+	switch tag {
+	case 's':
+		return pool.utf8(r.u2()), nil
+	case 'Z', 'B', 'C', 'S', 'I', 'J', 'F', 'D':
+		index := r.u2()
+		if int(index) >= len(pool) {
+			return nil, fmt.Errorf("constant pool index %d out of range", index)
+		}
+		bits := pool[index].bits
+		switch tag {
+		case 'Z':
+			return bits != 0, nil
+		case 'J':
+			return int64(bits), nil
+		case 'F':
+			return math.Float32frombits(uint32(bits)), nil
+		case 'D':
+			return math.Float64frombits(bits), nil
+		}
+		return int32(uint32(bits)), nil
+	case 'e':
+		return AnnotationEnum{Type: pool.utf8(r.u2()), Const: pool.utf8(r.u2())}, nil
+	case 'c':
+		return AnnotationClass(pool.utf8(r.u2())), nil
+	case '@':
+		return r.annotation(pool)
+	case '[':
+		count := int(r.u2())
+		values := make([]any, 0, count)
+		for i := 0; i < count && r.err == nil; i++ {
+			value, err := r.elementValue(pool)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, value)
+		}
+		return values, nil
+	}
+	if r.err != nil {
+		return nil, r.err
+	}
+	return nil, fmt.Errorf("unknown annotation element tag %q", tag)
+}
@@ -0,0 +1,113 @@
+package mcmodmeta
+
+import (
+	"sort"
+	"strings"
+)
+
+type (
+	// CollisionReport lists the commands and permissions that several Bukkit/Paper plugins fight over
+	CollisionReport struct {
+		Commands    []CommandCollision
+		Permissions []PermissionConflict
+	}
+
+	// CommandCollision is a command label (name or alias) registered by more than one plugin.
+	// Only one of them receives the bare label; the rest are reachable only through plugin:label.
+	CommandCollision struct {
+		Label         string
+		Registrations []CommandRegistration
+	}
+
+	// CommandRegistration is a single plugin's claim on a command label
+	CommandRegistration struct {
+		Plugin  string
+		Jar     string
+		Command string // The command the label belongs to, equal to the label unless it is an alias
+		Alias   bool
+	}
+
+	// PermissionConflict is a permission node declared by more than one plugin with different defaults
+	PermissionConflict struct {
+		Permission   string
+		Declarations []PermissionDeclaration
+	}
+
+	// PermissionDeclaration is a single plugin's declaration of a permission node
+	PermissionDeclaration struct {
+		Plugin  string
+		Jar     string
+		Default BukkitPermissionDefault
+	}
+)
+
+// FindCollisions reports command labels registered by several plugins and permission nodes
+// declared by several plugins with conflicting defaults, across a folder of scanned jars.
+// A jar shipping both plugin.yml and paper-plugin.yml counts as one plugin: its commands come from
+// plugin.yml, and its permissions from paper-plugin.yml as Paper prefers it when loading.
+func FindCollisions(jars []*JarMetadata) *CollisionReport {
+	commands := map[string][]CommandRegistration{}
+	permissions := map[string][]PermissionDeclaration{}
+
+	for _, jar := range jars {
+		for _, mod := range jar.ModsFor(PlatformBukkit) {
+			plugin := mod.Raw.(*BukkitPlugin)
+			for _, name := range sortedKeys(plugin.Commands) {
+				command := plugin.Commands[name]
+				label := strings.ToLower(name)
+				commands[label] = append(commands[label], CommandRegistration{Plugin: plugin.Name, Jar: jar.Path, Command: name})
+				for _, alias := range command.Aliases {
+					label := strings.ToLower(alias)
+					commands[label] = append(commands[label], CommandRegistration{Plugin: plugin.Name, Jar: jar.Path, Command: name, Alias: true})
+				}
+			}
+
+			if !jar.Supports(PlatformPaper) {
+				for _, node := range sortedKeys(plugin.Permissions) {
+					key := strings.ToLower(node)
+					permissions[key] = append(permissions[key], PermissionDeclaration{Plugin: plugin.Name, Jar: jar.Path, Default: plugin.EffectiveDefault(node)})
+				}
+			}
+		}
+
+		for _, mod := range jar.ModsFor(PlatformPaper) {
+			plugin := mod.Raw.(*PaperPlugin)
+			for _, node := range sortedKeys(plugin.Permissions) {
+				key := strings.ToLower(node)
+				permissions[key] = append(permissions[key], PermissionDeclaration{Plugin: plugin.Name, Jar: jar.Path, Default: plugin.EffectiveDefault(node)})
+			}
+		}
+	}
+
+	report := &CollisionReport{Commands: make([]CommandCollision, 0), Permissions: make([]PermissionConflict, 0)}
+	for label, registrations := range commands {
+		// A plugin listing a label as both its command and an alias only collides with itself
+		plugins := map[CommandRegistration]bool{}
+		for _, registration := range registrations {
+			plugins[CommandRegistration{Plugin: registration.Plugin, Jar: registration.Jar}] = true
+		}
+		if len(plugins) > 1 {
+			sort.SliceStable(registrations, func(i, j int) bool { return registrations[i].Plugin < registrations[j].Plugin })
+			report.Commands = append(report.Commands, CommandCollision{Label: label, Registrations: registrations})
+		}
+	}
+	for node, declarations := range permissions {
+		if len(declarations) < 2 {
+			continue
+		}
+		conflicting := false
+		for _, declaration := range declarations[1:] {
+			if declaration.Default != declarations[0].Default {
+				conflicting = true
+			}
+		}
+		if conflicting {
+			sort.SliceStable(declarations, func(i, j int) bool { return declarations[i].Plugin < declarations[j].Plugin })
+			report.Permissions = append(report.Permissions, PermissionConflict{Permission: node, Declarations: declarations})
+		}
+	}
+
+	sort.Slice(report.Commands, func(i, j int) bool { return report.Commands[i].Label < report.Commands[j].Label })
+	sort.Slice(report.Permissions, func(i, j int) bool { return report.Permissions[i].Permission < report.Permissions[j].Permission })
+	return report
+}
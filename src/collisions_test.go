@@ -0,0 +1,105 @@
+package mcmodmeta_test
+
+import (
+	mcmodmeta "mc-mod-metadata/src"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindCollisions(t *testing.T) {
+	tater := writeTestJar(t, map[string]string{
+		"plugin.yml": `name: TaterLib
+version: 0.1.0
+main: dev.neuralnexus.taterlib.Bukkit
+commands:
+  tater:
+    aliases: [ spawn, t ]
+permissions:
+  shared.node:
+    default: true
+  same.node:
+    default: op
+`,
+	})
+	essentials := writeTestJar(t, map[string]string{
+		"plugin.yml": `name: Essentials
+version: 2.0.0
+main: com.earth2me.essentials.Essentials
+commands:
+  spawn:
+    aliases: [ Tater ]
+  home: {}
+permissions:
+  shared.node:
+    default: false
+  same.node: {}
+`,
+	})
+	paper := writeTestJar(t, map[string]string{
+		"plugin.yml": `name: PaperThing
+version: 1.0.0
+main: dev.paper.Thing
+commands:
+  thing:
+    aliases: [ t ]
+permissions:
+  shared.node:
+    default: false
+`,
+		"paper-plugin.yml": `name: PaperThing
+version: 1.0.0
+main: dev.paper.Thing
+api-version: '1.20'
+default-permission: true
+permissions:
+  shared.node: {}
+`,
+	})
+
+	report := mcmodmeta.FindCollisions([]*mcmodmeta.JarMetadata{
+		mustReadJar(t, tater),
+		mustReadJar(t, essentials),
+		mustReadJar(t, paper),
+	})
+
+	assert.Equal(t, []mcmodmeta.CommandCollision{{
+		Label: "spawn",
+		Registrations: []mcmodmeta.CommandRegistration{
+			{Plugin: "Essentials", Jar: essentials, Command: "spawn"},
+			{Plugin: "TaterLib", Jar: tater, Command: "tater", Alias: true},
+		},
+	}, {
+		Label: "t",
+		Registrations: []mcmodmeta.CommandRegistration{
+			{Plugin: "PaperThing", Jar: paper, Command: "thing", Alias: true},
+			{Plugin: "TaterLib", Jar: tater, Command: "tater", Alias: true},
+		},
+	}, {
+		Label: "tater",
+		Registrations: []mcmodmeta.CommandRegistration{
+			{Plugin: "Essentials", Jar: essentials, Command: "spawn", Alias: true},
+			{Plugin: "TaterLib", Jar: tater, Command: "tater"},
+		},
+	}}, report.Commands)
+
+	assert.Equal(t, []mcmodmeta.PermissionConflict{{
+		Permission: "shared.node",
+		Declarations: []mcmodmeta.PermissionDeclaration{
+			{Plugin: "Essentials", Jar: essentials, Default: mcmodmeta.BukkitPermissionFalse},
+			{Plugin: "PaperThing", Jar: paper, Default: mcmodmeta.BukkitPermissionTrue},
+			{Plugin: "TaterLib", Jar: tater, Default: mcmodmeta.BukkitPermissionTrue},
+		},
+	}}, report.Permissions)
+}
+
+// mustReadJar reads a jar, failing the test if it cannot be opened
+func mustReadJar(t *testing.T, path string, opts ...mcmodmeta.Option) *mcmodmeta.JarMetadata {
+	t.Helper()
+
+	jar, err := mcmodmeta.ReadJarFile(path, opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return jar
+}
@@ -0,0 +1,467 @@
+package mcmodmeta
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Confidence is how much a source of compatibility information can be trusted
+type Confidence string
+
+const (
+	ConfidenceHigh   Confidence = "high"
+	ConfidenceMedium Confidence = "medium"
+	ConfidenceLow    Confidence = "low"
+)
+
+type (
+	// CompatibilityReport is the set of Minecraft versions a jar is inferred to run on
+	CompatibilityReport struct {
+		Ranges     []MinecraftVersionRange // Ranges from the most confident sources, empty if nothing is known
+		Confidence Confidence              // Confidence of the sources Ranges were taken from, empty if nothing is known
+		Sources    []CompatibilitySource   // Every source that was considered, most confident first
+	}
+
+	// CompatibilitySource is what a single place in the jar says about supported Minecraft versions
+	CompatibilitySource struct {
+		Source     string   // Where the information came from, e.g. "fabric.mod.json depends"
+		Mod        string   // ID of the declaring mod, empty for jar-wide sources such as the filename
+		Platform   Platform // Platform of the declaring mod, empty for jar-wide sources
+		Declared   string   // The value as written
+		Ranges     []MinecraftVersionRange
+		Confidence Confidence
+		Note       string
+	}
+
+	// MinecraftVersionRange is an interval of Minecraft versions, a nil bound is unbounded
+	MinecraftVersionRange struct {
+		Min          *MinecraftVersion
+		MinInclusive bool
+		Max          *MinecraftVersion
+		MaxInclusive bool
+	}
+)
+
+var (
+	// minecraftFilenameSeparatorPattern splits a jar's file name into tokens, e.g. sodium-fabric-mc1.20.1-0.5.3.jar
+	minecraftFilenameSeparatorPattern = regexp.MustCompile(`[-_+ ]`)
+
+	// minecraftFilenamePattern matches a token that looks like a release, optionally prefixed with mc
+	minecraftFilenamePattern = regexp.MustCompile(`(?i)^(?:mc)?(1\.\d{1,2}(?:\.\d{1,2})?)$`)
+)
+
+// confidenceRank orders confidences from most to least trusted
+var confidenceRank = []Confidence{ConfidenceHigh, ConfidenceMedium, ConfidenceLow}
+
+// MinecraftCompatibility infers the Minecraft versions the jar runs on. Declared minecraft dependencies in
+// fabric.mod.json, quilt.mod.json and mods.toml, and @Mod's acceptedMinecraftVersions, are trusted most; mcmod.info's mcversion, plugin.yml's
+// api-version and the manifest's Fabric-Minecraft-Version less; release numbers in the file name least.
+// Ranges combines the sources of the highest confidence present, across every platform the jar supports,
+// ordered by their lower bound.
+func (jar *JarMetadata) MinecraftCompatibility() *CompatibilityReport {
+	report := &CompatibilityReport{Ranges: make([]MinecraftVersionRange, 0), Sources: make([]CompatibilitySource, 0)}
+
+	for _, mod := range jar.Mods {
+		switch raw := mod.Raw.(type) {
+		case *ForgeLegacyMod:
+			if raw.MCVersion != "" {
+				report.addSource(minecraftSourceFromMaven(CompatibilitySource{
+					Source:     mod.Source + " mcversion",
+					Declared:   raw.MCVersion,
+					Confidence: ConfidenceMedium,
+					Note:       "mcversion is often left unchanged between ports",
+				}, mod))
+			}
+			continue
+		case *BukkitPlugin:
+			report.addSource(minecraftSourceFromAPIVersion(raw.APIVersion, mod))
+			continue
+		case *PaperPlugin:
+			report.addSource(minecraftSourceFromAPIVersion(raw.APIVersion, mod))
+			continue
+		case *ForgeModAnnotation:
+			continue
+		}
+
+		for _, dep := range mod.Dependencies {
+			if dep.ID != "minecraft" || dep.Kind != DependencyRequired {
+				continue
+			}
+			source := CompatibilitySource{Declared: dep.VersionRange, Confidence: ConfidenceHigh}
+			switch mod.Platform {
+			case PlatformFabric, PlatformQuilt:
+				source.Source = mod.Source + " depends"
+				report.addSource(minecraftSourceFromFabric(source, mod))
+			case PlatformForge, PlatformNeoForge, PlatformSponge:
+				source.Source = mod.Source + " minecraft dependency"
+				report.addSource(minecraftSourceFromMaven(source, mod))
+			}
+		}
+	}
+
+	for _, annotation := range jar.ModAnnotations {
+		if annotation.AcceptedMinecraftVersions == "" {
+			continue
+		}
+		source := CompatibilitySource{
+			Source:     annotation.Path + " acceptedMinecraftVersions",
+			Declared:   annotation.AcceptedMinecraftVersions,
+			Confidence: ConfidenceHigh,
+		}
+		report.addSource(minecraftSourceFromMaven(source, &ModMetadata{ID: annotation.ModID, Platform: PlatformForge}))
+	}
+
+	if jar.Manifest != nil {
+		if version := jar.Manifest.FabricMinecraftVersion(); version != "" {
+			source := CompatibilitySource{
+				Source:     "META-INF/MANIFEST.MF Fabric-Minecraft-Version",
+				Declared:   version,
+				Confidence: ConfidenceMedium,
+				Note:       "the version the jar was built against",
+			}
+			if parsed, err := parseMinecraftBound(version); err != nil {
+				source.Note = err.Error()
+			} else {
+				source.Ranges = []MinecraftVersionRange{exactMinecraftRange(parsed)}
+			}
+			report.addSource(source)
+		}
+	}
+
+	report.addSource(minecraftSourceFromFilename(jar))
+
+	sort.SliceStable(report.Sources, func(i, j int) bool {
+		return slices.Index(confidenceRank, report.Sources[i].Confidence) < slices.Index(confidenceRank, report.Sources[j].Confidence)
+	})
+	for _, source := range report.Sources {
+		if len(source.Ranges) == 0 {
+			continue
+		}
+		if report.Confidence == "" {
+			report.Confidence = source.Confidence
+		} else if source.Confidence != report.Confidence {
+			break
+		}
+		for _, versionRange := range source.Ranges {
+			if !slices.ContainsFunc(report.Ranges, func(existing MinecraftVersionRange) bool { return existing.String() == versionRange.String() }) {
+				report.Ranges = append(report.Ranges, versionRange)
+			}
+		}
+	}
+	sort.SliceStable(report.Ranges, func(i, j int) bool {
+		left, right := report.Ranges[i].Min, report.Ranges[j].Min
+		return right != nil && (left == nil || left.Compare(right) < 0)
+	})
+	return report
+}
+
+// addSource records a source, skipping empty ones
+func (report *CompatibilityReport) addSource(source CompatibilitySource) {
+	if source.Source != "" {
+		report.Sources = append(report.Sources, source)
+	}
+}
+
+// Supports reports whether the version is within any of the inferred ranges
+func (report *CompatibilityReport) Supports(version string) bool {
+	parsed, err := ParseMinecraftVersion(version)
+	if err != nil {
+		return false
+	}
+	return slices.ContainsFunc(report.Ranges, func(versionRange MinecraftVersionRange) bool { return versionRange.Contains(parsed) })
+}
+
+// minecraftSourceFromFabric converts a Fabric or Quilt minecraft dependency predicate into ranges
+func minecraftSourceFromFabric(source CompatibilitySource, mod *ModMetadata) CompatibilitySource {
+	source.Mod, source.Platform = mod.ID, mod.Platform
+	if strings.Contains(source.Declared, "${") {
+		source.Note = "unresolved placeholder"
+		return source
+	}
+	predicate, err := ParseFabricVersionPredicate(source.Declared)
+	if err != nil {
+		source.Note = err.Error()
+		return source
+	}
+	ranges, err := predicate.minecraftRanges()
+	if err != nil {
+		source.Note = err.Error()
+		return source
+	}
+	source.Ranges = ranges
+	return source
+}
+
+// minecraftSourceFromMaven converts a Maven version range, or a bare version, into ranges
+func minecraftSourceFromMaven(source CompatibilitySource, mod *ModMetadata) CompatibilitySource {
+	source.Mod, source.Platform = mod.ID, mod.Platform
+	declared := strings.TrimSpace(source.Declared)
+	if declared == "" || declared == "*" {
+		source.Ranges = []MinecraftVersionRange{{}}
+		return source
+	}
+	if strings.Contains(declared, "${") {
+		source.Note = "unresolved placeholder"
+		return source
+	}
+
+	mavenRange, err := ParseMavenVersionRange(declared)
+	if err != nil {
+		source.Note = err.Error()
+		return source
+	}
+	// A bare version is a soft requirement to Maven, but always means that exact version in practice
+	if mavenRange.Recommended != nil {
+		version, err := parseMinecraftBound(declared)
+		if err != nil {
+			source.Note = err.Error()
+			return source
+		}
+		source.Ranges = []MinecraftVersionRange{exactMinecraftRange(version)}
+		return source
+	}
+
+	for _, restriction := range mavenRange.Restrictions {
+		versionRange := MinecraftVersionRange{MinInclusive: restriction.LowerInclusive, MaxInclusive: restriction.UpperInclusive}
+		if restriction.Lower != nil {
+			if versionRange.Min, err = parseMinecraftBound(restriction.Lower.Raw); err != nil {
+				source.Note = err.Error()
+				return source
+			}
+		}
+		if restriction.Upper != nil {
+			if versionRange.Max, err = parseMinecraftBound(restriction.Upper.Raw); err != nil {
+				source.Note = err.Error()
+				return source
+			}
+		}
+		source.Ranges = append(source.Ranges, versionRange)
+	}
+	return source
+}
+
+// minecraftSourceFromAPIVersion converts a plugin.yml api-version, the oldest API the plugin was written for
+func minecraftSourceFromAPIVersion(apiVersion string, mod *ModMetadata) CompatibilitySource {
+	if apiVersion == "" {
+		return CompatibilitySource{}
+	}
+	source := CompatibilitySource{
+		Source:     mod.Source + " api-version",
+		Mod:        mod.ID,
+		Platform:   mod.Platform,
+		Declared:   apiVersion,
+		Confidence: ConfidenceMedium,
+		Note:       "api-version only sets a minimum",
+	}
+	version, err := parseMinecraftBound(apiVersion)
+	if err != nil {
+		source.Note = err.Error()
+		return source
+	}
+	source.Ranges = []MinecraftVersionRange{{Min: version, MinInclusive: true}}
+	return source
+}
+
+// minecraftSourceFromFilename looks for known release numbers in the jar's file name, ignoring the mods' own versions
+func minecraftSourceFromFilename(jar *JarMetadata) CompatibilitySource {
+	name := filepath.Base(jar.Path)
+	source := CompatibilitySource{
+		Source:     "filename",
+		Declared:   name,
+		Confidence: ConfidenceLow,
+		Note:       "guessed from release numbers in the file name",
+	}
+
+	releases := MinecraftReleases()
+	tokens := minecraftFilenameSeparatorPattern.Split(strings.TrimSuffix(strings.TrimSuffix(name, ".jar"), ".JAR"), -1)
+	for _, token := range tokens {
+		match := minecraftFilenamePattern.FindStringSubmatch(token)
+		if match == nil || !slices.Contains(releases, match[1]) ||
+			slices.ContainsFunc(jar.Mods, func(mod *ModMetadata) bool { return mod.Version == match[1] }) {
+			continue
+		}
+		version, _ := ParseMinecraftVersion(match[1])
+		versionRange := exactMinecraftRange(version)
+		if !slices.ContainsFunc(source.Ranges, func(existing MinecraftVersionRange) bool { return existing.String() == versionRange.String() }) {
+			source.Ranges = append(source.Ranges, versionRange)
+		}
+	}
+	if len(source.Ranges) == 0 {
+		return CompatibilitySource{}
+	}
+	return source
+}
+
+// parseMinecraftBound parses a version used as a range bound. Fabric normalizes snapshots and pre-releases
+// into SemVer pre-releases (1.20.5-alpha.24.12.a), which are approximated by their release. An empty
+// pre-release, as in 1.21-, stands for the start of the release's development, before its first snapshot.
+func parseMinecraftBound(version string) (*MinecraftVersion, error) {
+	parsed, err := ParseMinecraftVersion(version)
+	if err == nil {
+		return parsed, nil
+	}
+	if release, prerelease, found := strings.Cut(version, "-"); found {
+		if parsed, releaseErr := ParseMinecraftVersion(release); releaseErr == nil && parsed.IsRelease() {
+			if prerelease == "" {
+				return developmentStart(parsed), nil
+			}
+			return parsed, nil
+		}
+	}
+	return nil, err
+}
+
+// developmentStart returns the point before a release's first snapshot, written like Fabric as the release with an
+// empty pre-release, e.g. 1.21-
+func developmentStart(release *MinecraftVersion) *MinecraftVersion {
+	start := *release
+	start.Raw, start.Type, start.phase = release.Release+"-", MinecraftSnapshot, minecraftPhaseSnapshot
+	return &start
+}
+
+// exactMinecraftRange returns a range containing only the given version
+func exactMinecraftRange(version *MinecraftVersion) MinecraftVersionRange {
+	return MinecraftVersionRange{Min: version, MinInclusive: true, Max: version, MaxInclusive: true}
+}
+
+// minecraftRanges converts each alternative of a Fabric predicate into a single range
+func (p *FabricVersionPredicate) minecraftRanges() ([]MinecraftVersionRange, error) {
+	ranges := make([]MinecraftVersionRange, 0, len(p.alternatives))
+	for _, terms := range p.alternatives {
+		versionRange := MinecraftVersionRange{}
+		for _, term := range terms {
+			termRange, err := term.minecraftRange()
+			if err != nil {
+				return nil, err
+			}
+			versionRange = versionRange.intersect(termRange)
+		}
+		ranges = append(ranges, versionRange)
+	}
+	return ranges, nil
+}
+
+// minecraftRange converts a single predicate term into a range
+func (t fabricPredicateTerm) minecraftRange() (MinecraftVersionRange, error) {
+	version := t.version
+	if version.hasWildcard() {
+		prefix := version.Components[:len(version.Components)-1]
+		if len(prefix) == 0 {
+			return MinecraftVersionRange{}, nil
+		}
+		return componentRange(prefix, len(prefix)-1)
+	}
+
+	bound, err := parseMinecraftBound(version.Raw)
+	if err != nil {
+		return MinecraftVersionRange{}, err
+	}
+	switch t.operator {
+	case ">=":
+		return MinecraftVersionRange{Min: bound, MinInclusive: true}, nil
+	case ">":
+		return MinecraftVersionRange{Min: bound}, nil
+	case "<=":
+		return MinecraftVersionRange{Max: bound, MaxInclusive: true}, nil
+	case "<":
+		return MinecraftVersionRange{Max: bound}, nil
+	case "~":
+		versionRange, err := componentRange([]int{version.component(0), version.component(1)}, 1)
+		versionRange.Min = bound
+		return versionRange, err
+	case "^":
+		versionRange, err := componentRange([]int{version.component(0)}, 0)
+		versionRange.Min = bound
+		return versionRange, err
+	}
+	return exactMinecraftRange(bound), nil
+}
+
+// componentRange returns the range from a version up to the next increment of its n-th component. Like Fabric's
+// own predicates, it includes the snapshots and pre-releases of the version, but not those of the next increment.
+func componentRange(components []int, n int) (MinecraftVersionRange, error) {
+	upper := slices.Clone(components[:n+1])
+	upper[n]++
+	lower, err := ParseMinecraftVersion(joinComponents(components))
+	if err != nil {
+		return MinecraftVersionRange{}, err
+	}
+	next, err := ParseMinecraftVersion(joinComponents(upper))
+	if err != nil {
+		return MinecraftVersionRange{}, err
+	}
+	return MinecraftVersionRange{Min: developmentStart(lower), MinInclusive: true, Max: developmentStart(next)}, nil
+}
+
+// joinComponents formats version numbers as a dotted version, with at least two components as Minecraft uses
+func joinComponents(components []int) string {
+	parts := make([]string, 0, max(len(components), 2))
+	for _, component := range components {
+		parts = append(parts, strconv.Itoa(component))
+	}
+	if len(parts) == 1 {
+		parts = append(parts, "0")
+	}
+	return strings.Join(parts, ".")
+}
+
+// intersect narrows the range to the versions also within other
+func (r MinecraftVersionRange) intersect(other MinecraftVersionRange) MinecraftVersionRange {
+	if other.Min != nil {
+		if r.Min == nil {
+			r.Min, r.MinInclusive = other.Min, other.MinInclusive
+		} else if cmp := other.Min.Compare(r.Min); cmp > 0 || (cmp == 0 && !other.MinInclusive) {
+			r.Min, r.MinInclusive = other.Min, other.MinInclusive
+		}
+	}
+	if other.Max != nil {
+		if r.Max == nil {
+			r.Max, r.MaxInclusive = other.Max, other.MaxInclusive
+		} else if cmp := other.Max.Compare(r.Max); cmp < 0 || (cmp == 0 && !other.MaxInclusive) {
+			r.Max, r.MaxInclusive = other.Max, other.MaxInclusive
+		}
+	}
+	return r
+}
+
+// Contains reports whether the version is within the range
+func (r MinecraftVersionRange) Contains(version *MinecraftVersion) bool {
+	if r.Min != nil {
+		if cmp := version.Compare(r.Min); cmp < 0 || (cmp == 0 && !r.MinInclusive) {
+			return false
+		}
+	}
+	if r.Max != nil {
+		if cmp := version.Compare(r.Max); cmp > 0 || (cmp == 0 && !r.MaxInclusive) {
+			return false
+		}
+	}
+	return true
+}
+
+// String formats the range in interval notation, e.g. [1.20,1.21) or [1.20.1]
+func (r MinecraftVersionRange) String() string {
+	if r.Min != nil && r.Max != nil && r.MinInclusive && r.MaxInclusive && r.Min.Compare(r.Max) == 0 {
+		return fmt.Sprintf("[%s]", r.Min)
+	}
+	lower, upper := "(", ")"
+	var low, high string
+	if r.Min != nil {
+		low = r.Min.String()
+		if r.MinInclusive {
+			lower = "["
+		}
+	}
+	if r.Max != nil {
+		high = r.Max.String()
+		if r.MaxInclusive {
+			upper = "]"
+		}
+	}
+	return lower + low + "," + high + upper
+}
@@ -0,0 +1,132 @@
+package mcmodmeta_test
+
+import (
+	mcmodmeta "mc-mod-metadata/src"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMinecraftCompatibilityFabric(t *testing.T) {
+	jar := mustReadJar(t, writeTestJar(t, map[string]string{
+		"fabric.mod.json":      `{"schemaVersion": 1, "id": "taterlib", "version": "1.2.0", "depends": {"minecraft": [">=1.20 <1.20.5", "1.21.x"]}}`,
+		"META-INF/MANIFEST.MF": "Manifest-Version: 1.0\r\nFabric-Minecraft-Version: 1.20.1\r\n",
+	}))
+
+	report := jar.MinecraftCompatibility()
+
+	assert.Equal(t, mcmodmeta.ConfidenceHigh, report.Confidence)
+	assert.Equal(t, 2, len(report.Ranges))
+	assert.Equal(t, "[1.20,1.20.5)", report.Ranges[0].String())
+	assert.Equal(t, "[1.21-,1.22-)", report.Ranges[1].String())
+	assert.True(t, report.Supports("1.20.4"))
+	assert.True(t, report.Supports("1.21.4"))
+	assert.False(t, report.Supports("1.20.5"))
+	assert.True(t, report.Supports("24w14a"))
+	assert.True(t, report.Supports("24w18a"))
+	assert.True(t, report.Supports("1.21-pre1"))
+	assert.False(t, report.Supports("1.22-pre1"))
+
+	assert.Equal(t, 2, len(report.Sources))
+	assert.Equal(t, "fabric.mod.json depends", report.Sources[0].Source)
+	assert.Equal(t, "taterlib", report.Sources[0].Mod)
+	assert.Equal(t, "META-INF/MANIFEST.MF Fabric-Minecraft-Version", report.Sources[1].Source)
+	assert.Equal(t, mcmodmeta.ConfidenceMedium, report.Sources[1].Confidence)
+	assert.Equal(t, "[1.20.1]", report.Sources[1].Ranges[0].String())
+}
+
+func TestMinecraftCompatibilityFabricPrerelease(t *testing.T) {
+	jar := mustReadJar(t, writeTestJar(t, map[string]string{
+		"fabric.mod.json": `{"schemaVersion": 1, "id": "taterlib", "version": "1.2.0", "depends": {"minecraft": ">=1.21-"}}`,
+	}))
+
+	report := jar.MinecraftCompatibility()
+
+	assert.Equal(t, "[1.21-,)", report.Ranges[0].String())
+	assert.True(t, report.Supports("1.21.1"))
+	assert.True(t, report.Supports("1.21-rc1"))
+	assert.True(t, report.Supports("24w18a"))
+	assert.False(t, report.Supports("1.20.6"))
+}
+
+func TestMinecraftCompatibilityFabricWildcard(t *testing.T) {
+	jar := mustReadJar(t, writeTestJar(t, map[string]string{
+		"fabric.mod.json": `{"schemaVersion": 1, "id": "taterlib", "version": "1.2.0", "depends": {"minecraft": "1.20.x"}}`,
+	}))
+
+	report := jar.MinecraftCompatibility()
+	predicate, err := mcmodmeta.ParseFabricVersionPredicate("1.20.x")
+
+	assert.Nil(t, err)
+	assert.Equal(t, "[1.20-,1.21-)", report.Ranges[0].String())
+	for version, expected := range map[string]bool{"1.20-pre1": true, "1.20": true, "1.20.6": true, "1.21-pre1": false, "1.19.4": false} {
+		assert.Equal(t, expected, report.Supports(version), version)
+		assert.Equal(t, expected, predicate.Test(version), version)
+	}
+}
+
+func TestMinecraftCompatibilityMultiPlatform(t *testing.T) {
+	jar := mustReadJar(t, writeTestJar(t, map[string]string{
+		"fabric.mod.json": `{"schemaVersion": 1, "id": "taterlib", "version": "1.2.0", "depends": {"minecraft": "~1.20.1"}}`,
+		"META-INF/mods.toml": "modLoader = \"javafml\"\nloaderVersion = \"[47,)\"\nlicense = \"MIT\"\n[[mods]]\nmodId = \"taterlib\"\n" +
+			"[[dependencies.taterlib]]\nmodId = \"minecraft\"\nmandatory = true\nversionRange = \"[1.19.2],[1.20.1,1.21)\"\n",
+		"mcmod.info": `[{"modid": "taterlib", "mcversion": "1.12.2"}]`,
+		"plugin.yml": "name: TaterLib\nversion: 1.2.0\nmain: dev.neuralnexus.taterlib.Bukkit\napi-version: '1.13'\n",
+	}))
+
+	report := jar.MinecraftCompatibility()
+
+	assert.Equal(t, mcmodmeta.ConfidenceHigh, report.Confidence)
+	assert.Equal(t, []string{"[1.19.2]", "[1.20.1,1.21)", "[1.20.1,1.21-)"}, rangeStrings(report.Ranges))
+	assert.Equal(t, 4, len(report.Sources))
+
+	for _, source := range report.Sources {
+		switch source.Platform {
+		case mcmodmeta.PlatformForge:
+			if source.Source == "mcmod.info mcversion" {
+				assert.Equal(t, []string{"[1.12.2]"}, rangeStrings(source.Ranges))
+			}
+		case mcmodmeta.PlatformBukkit:
+			assert.Equal(t, "plugin.yml api-version", source.Source)
+			assert.Equal(t, []string{"[1.13,)"}, rangeStrings(source.Ranges))
+		}
+	}
+}
+
+func TestMinecraftCompatibilityFilename(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "taterlib-fabric-1.20.1-1.2.0.jar")
+	if err := os.Rename(writeTestJar(t, map[string]string{
+		"fabric.mod.json": `{"schemaVersion": 1, "id": "taterlib", "version": "1.2.0"}`,
+	}), path); err != nil {
+		t.Fatal(err)
+	}
+
+	report := mustReadJar(t, path).MinecraftCompatibility()
+
+	assert.Equal(t, mcmodmeta.ConfidenceLow, report.Confidence)
+	assert.Equal(t, []string{"[1.20.1]"}, rangeStrings(report.Ranges))
+	assert.Equal(t, "filename", report.Sources[0].Source)
+
+	for name, expected := range map[string][]string{
+		"mod-1.20.1-1.21.jar":              {"[1.20.1]", "[1.21]"},
+		"sodium-fabric-mc1.20.1-0.5.3.jar": {"[1.20.1]"},
+		"create_1.19.2+1.20.1-0.5.1.f.jar": {"[1.19.2]", "[1.20.1]"},
+		"jei-1.20.1-forge-15.2.0.27.jar":   {"[1.20.1]"},
+	} {
+		path := filepath.Join(t.TempDir(), name)
+		if err := os.Rename(writeTestJar(t, map[string]string{"plugin.yml": "name: Mod\nversion: 2.0.0\nmain: dev.neuralnexus.Mod\n"}), path); err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, expected, rangeStrings(mustReadJar(t, path).MinecraftCompatibility().Ranges), name)
+	}
+}
+
+func rangeStrings(ranges []mcmodmeta.MinecraftVersionRange) []string {
+	strs := make([]string, 0, len(ranges))
+	for _, versionRange := range ranges {
+		strs = append(strs, versionRange.String())
+	}
+	return strs
+}
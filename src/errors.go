@@ -0,0 +1,52 @@
+package mcmodmeta
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrNotJar is returned when a file is not a readable zip archive
+	ErrNotJar = errors.New("not a zip archive")
+
+	// ErrUnsupportedDescriptor is returned for zip entries that are not a descriptor this library understands
+	ErrUnsupportedDescriptor = errors.New("unsupported descriptor")
+
+	// ErrMalformedDescriptor matches every MalformedDescriptorError via errors.Is
+	ErrMalformedDescriptor = errors.New("malformed descriptor")
+
+	// ErrMissingField matches every MissingFieldError via errors.Is
+	ErrMissingField = errors.New("missing required field")
+)
+
+// MalformedDescriptorError is returned when a descriptor inside a jar could not be read or decoded
+type MalformedDescriptorError struct {
+	Path string // Path of the descriptor inside the jar
+	Err  error  // The underlying read or decode error
+}
+
+func (e *MalformedDescriptorError) Error() string {
+	return fmt.Sprintf("%s: %s: %v", e.Path, ErrMalformedDescriptor, e.Err)
+}
+
+func (e *MalformedDescriptorError) Unwrap() error {
+	return e.Err
+}
+
+func (e *MalformedDescriptorError) Is(target error) bool {
+	return target == ErrMalformedDescriptor
+}
+
+// MissingFieldError is returned when a descriptor decodes but lacks a field its loader requires
+type MissingFieldError struct {
+	Path  string // Path of the descriptor inside the jar
+	Field string // Name of the field as written in the descriptor, e.g. modId
+}
+
+func (e *MissingFieldError) Error() string {
+	return fmt.Sprintf("%s: %s %q", e.Path, ErrMissingField, e.Field)
+}
+
+func (e *MissingFieldError) Is(target error) bool {
+	return target == ErrMissingField
+}
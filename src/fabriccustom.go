@@ -0,0 +1,169 @@
+package mcmodmeta
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"sync"
+
+	"github.com/goccy/go-json"
+)
+
+// FabricCustomDecoder decodes the value of a key in fabric.mod.json's custom block into a typed value
+type FabricCustomDecoder func(data json.RawMessage) (any, error)
+
+type (
+	// ModMenuMetadata is the modmenu custom block, read by Mod Menu to display and group mods
+	ModMenuMetadata struct {
+		Links         map[string]string `json:"links"`          // Extra links keyed by their translation key, e.g. modmenu.discord
+		Badges        []string          `json:"badges"`         // library, client or deprecated
+		Parent        *ModMenuParent    `json:"parent"`         // The mod this one is listed under, nil if it is top level
+		UpdateChecker bool              `json:"update_checker"` // Whether Mod Menu checks for updates, true unless disabled
+	}
+
+	// ModMenuParent is a parent mod, written as the ID of an installed mod or an object describing a placeholder parent
+	ModMenuParent struct {
+		ID          string   `json:"id"`
+		Name        string   `json:"name"`
+		Description string   `json:"description"`
+		Icon        string   `json:"icon"`
+		Badges      []string `json:"badges"`
+	}
+
+	// LoomInjectedInterfaces maps a Minecraft class to the interfaces Loom injects into it
+	LoomInjectedInterfaces map[string][]string
+
+	// LithiumOptions enables or disables Lithium mixin options, keyed by option name
+	LithiumOptions map[string]bool
+)
+
+var (
+	fabricCustomDecodersMu sync.RWMutex
+	fabricCustomDecoders   = map[string]FabricCustomDecoder{
+		"modmenu":                  decodeFabricCustom[ModMenuMetadata],
+		"loom:injected_interfaces": decodeFabricCustom[LoomInjectedInterfaces],
+		"lithium:options":          decodeFabricCustom[LithiumOptions],
+	}
+)
+
+// RegisterFabricCustomDecoder registers a decoder for a custom key, replacing any decoder already registered for it
+func RegisterFabricCustomDecoder(key string, decoder FabricCustomDecoder) {
+	fabricCustomDecodersMu.Lock()
+	defer fabricCustomDecodersMu.Unlock()
+	fabricCustomDecoders[key] = decoder
+}
+
+// FabricCustomKeys returns every custom key a decoder is registered for
+func FabricCustomKeys() []string {
+	fabricCustomDecodersMu.RLock()
+	defer fabricCustomDecodersMu.RUnlock()
+	keys := make([]string, 0, len(fabricCustomDecoders))
+	for key := range fabricCustomDecoders {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// decodeFabricCustom decodes a custom value into a *T
+func decodeFabricCustom[T any](data json.RawMessage) (any, error) {
+	value := new(T)
+	if err := json.Unmarshal(data, value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// CustomValue decodes a custom key with its registered decoder. ok is false if the mod does not set the key;
+// keys without a registered decoder are returned as json.RawMessage.
+func (m *FabricMod) CustomValue(key string) (value any, ok bool, err error) {
+	data, ok := m.Custom[key]
+	if !ok {
+		return nil, false, nil
+	}
+
+	fabricCustomDecodersMu.RLock()
+	decoder, registered := fabricCustomDecoders[key]
+	fabricCustomDecodersMu.RUnlock()
+	if !registered {
+		return data, true, nil
+	}
+
+	value, err = decoder(data)
+	if err != nil {
+		return nil, true, fmt.Errorf("custom %s: %w", key, err)
+	}
+	return value, true, nil
+}
+
+// ModMenu returns the mod's Mod Menu metadata, including the legacy modmenu:api, modmenu:clientsideOnly
+// and modmenu:parent keys. It is nil if the mod sets none of them.
+func (m *FabricMod) ModMenu() (*ModMenuMetadata, error) {
+	var modMenu *ModMenuMetadata
+	value, ok, err := m.CustomValue("modmenu")
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		modMenu, ok = value.(*ModMenuMetadata)
+		if !ok {
+			return nil, fmt.Errorf("custom modmenu: decoded as %T", value)
+		}
+	}
+
+	var api, clientsideOnly bool
+	var parent *ModMenuParent
+	for key, target := range map[string]any{
+		"modmenu:api":            &api,
+		"modmenu:clientsideOnly": &clientsideOnly,
+		"modmenu:parent":         &parent,
+	} {
+		if data, ok := m.Custom[key]; ok {
+			if err := json.Unmarshal(data, target); err != nil {
+				return nil, fmt.Errorf("custom %s: %w", key, err)
+			}
+		}
+	}
+	if modMenu == nil && !api && !clientsideOnly && parent == nil {
+		return nil, nil
+	}
+
+	if modMenu == nil {
+		modMenu = &ModMenuMetadata{UpdateChecker: true}
+	}
+	if api {
+		modMenu.Badges = mergeLists(modMenu.Badges, []string{"library"})
+	}
+	if clientsideOnly {
+		modMenu.Badges = mergeLists(modMenu.Badges, []string{"client"})
+	}
+	if modMenu.Parent == nil {
+		modMenu.Parent = parent
+	}
+	return modMenu, nil
+}
+
+func (m *ModMenuMetadata) UnmarshalJSON(data []byte) error {
+	type plain ModMenuMetadata
+	decoded := plain{UpdateChecker: true}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	*m = ModMenuMetadata(decoded)
+	return nil
+}
+
+func (p *ModMenuParent) UnmarshalJSON(data []byte) error {
+	var id string
+	if err := json.Unmarshal(data, &id); err == nil {
+		*p = ModMenuParent{ID: id}
+		return nil
+	}
+	type plain ModMenuParent
+	return json.Unmarshal(data, (*plain)(p))
+}
+
+// HasBadge reports whether Mod Menu shows the given badge for the mod
+func (m *ModMenuMetadata) HasBadge(badge string) bool {
+	return slices.Contains(m.Badges, badge)
+}
@@ -0,0 +1,106 @@
+package mcmodmeta_test
+
+import (
+	mcmodmeta "mc-mod-metadata/src"
+	"testing"
+
+	"github.com/goccy/go-json"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFabricCustomModMenu(t *testing.T) {
+	fabricMod, err := mcmodmeta.NewFabricMod(`{
+  "schemaVersion": 1,
+  "id": "taterlib-fabric",
+  "version": "1.0.0",
+  "custom": {
+    "modmenu": {
+      "links": {"modmenu.discord": "https://discord.example"},
+      "badges": ["library"],
+      "parent": {"id": "taterlib", "name": "TaterLib", "badges": ["library"]}
+    },
+    "loom:injected_interfaces": {"net/minecraft/class_1657": ["dev/neuralnexus/taterlib/Player"]},
+    "lithium:options": {"mixin.ai.pathing": false},
+    "someothermod": {"anything": 1}
+  }
+}`)
+	assert.Nil(t, err)
+
+	modMenu, err := fabricMod.ModMenu()
+
+	assert.Nil(t, err)
+	assert.True(t, modMenu.HasBadge("library"))
+	assert.Equal(t, "https://discord.example", modMenu.Links["modmenu.discord"])
+	assert.Equal(t, "taterlib", modMenu.Parent.ID)
+	assert.Equal(t, "TaterLib", modMenu.Parent.Name)
+	assert.True(t, modMenu.UpdateChecker)
+
+	injected, ok, err := fabricMod.CustomValue("loom:injected_interfaces")
+
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, []string{"dev/neuralnexus/taterlib/Player"}, (*injected.(*mcmodmeta.LoomInjectedInterfaces))["net/minecraft/class_1657"])
+
+	lithium, _, err := fabricMod.CustomValue("lithium:options")
+
+	assert.Nil(t, err)
+	assert.Equal(t, false, (*lithium.(*mcmodmeta.LithiumOptions))["mixin.ai.pathing"])
+
+	raw, ok, err := fabricMod.CustomValue("someothermod")
+
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	assert.JSONEq(t, `{"anything": 1}`, string(raw.(json.RawMessage)))
+
+	_, ok, err = fabricMod.CustomValue("missing")
+
+	assert.Nil(t, err)
+	assert.False(t, ok)
+}
+
+func TestFabricCustomModMenuLegacy(t *testing.T) {
+	fabricMod, err := mcmodmeta.NewFabricMod(`{
+  "schemaVersion": 1,
+  "id": "taterlib-fabric",
+  "version": "1.0.0",
+  "custom": {"modmenu:api": true, "modmenu:clientsideOnly": true, "modmenu:parent": "taterlib", "modmenu": {"update_checker": false}}
+}`)
+	assert.Nil(t, err)
+
+	modMenu, err := fabricMod.ModMenu()
+
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"library", "client"}, modMenu.Badges)
+	assert.Equal(t, &mcmodmeta.ModMenuParent{ID: "taterlib"}, modMenu.Parent)
+	assert.False(t, modMenu.UpdateChecker)
+
+	plain, err := mcmodmeta.NewFabricMod(`{"schemaVersion": 1, "id": "taterlib", "version": "1.0.0"}`)
+	assert.Nil(t, err)
+	modMenu, err = plain.ModMenu()
+	assert.Nil(t, err)
+	assert.Nil(t, modMenu)
+}
+
+func TestRegisterFabricCustomDecoder(t *testing.T) {
+	type cardinalComponents struct {
+		Components []string
+	}
+	mcmodmeta.RegisterFabricCustomDecoder("cardinal-components", func(data json.RawMessage) (any, error) {
+		components := &cardinalComponents{}
+		return components, json.Unmarshal(data, &components.Components)
+	})
+
+	fabricMod, err := mcmodmeta.NewFabricMod(`{"schemaVersion": 1, "id": "taterlib", "version": "1.0.0", "custom": {"cardinal-components": ["taterlib:data"]}}`)
+	assert.Nil(t, err)
+
+	value, ok, err := fabricMod.CustomValue("cardinal-components")
+
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, []string{"taterlib:data"}, value.(*cardinalComponents).Components)
+	assert.Contains(t, mcmodmeta.FabricCustomKeys(), "cardinal-components")
+	assert.Contains(t, mcmodmeta.FabricCustomKeys(), "modmenu")
+
+	_, _, err = fabricMod.CustomValue("modmenu")
+	assert.Nil(t, err)
+}
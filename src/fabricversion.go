@@ -0,0 +1,319 @@
+package mcmodmeta
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+type (
+	// FabricVersion is a version as Fabric Loader understands it: SemVer where possible,
+	// and otherwise an opaque string that only supports equality
+	FabricVersion struct {
+		Components []int  // Numeric components, with -1 standing for an x/X/* wildcard in predicates
+		Prerelease string // Text after the first '-', without build metadata
+		Build      string // Text after the first '+'
+		Raw        string
+		Semantic   bool
+
+		prerelease bool // Whether there is a '-', since the pre-release may be empty as in 1.21-
+	}
+
+	// FabricVersionPredicate is a parsed fabric.mod.json dependency predicate.
+	// Space separated terms must all match, and alternatives separated by || (or given as a list) may match.
+	FabricVersionPredicate struct {
+		alternatives [][]fabricPredicateTerm
+	}
+
+	// fabricPredicateTerm is a single operator and version, e.g. >=1.20
+	fabricPredicateTerm struct {
+		operator string
+		version  *FabricVersion
+	}
+)
+
+var (
+	// An empty pre-release is allowed, so that >=1.21- also matches 1.21's pre-releases and release candidates
+	fabricPrereleasePattern = regexp.MustCompile(`^([0-9A-Za-z-]+(\.[0-9A-Za-z-]+)*)?$`)
+	fabricBuildPattern      = regexp.MustCompile(`^[0-9A-Za-z-]+(\.[0-9A-Za-z-]+)*$`)
+	fabricOperators         = []string{">=", "<=", ">", "<", "=", "~", "^"}
+
+	errFabricWildcard = errors.New("invalid wildcard")
+)
+
+// ParseFabricVersion parses a version the way Fabric Loader does, falling back to an opaque string version
+func ParseFabricVersion(version string) *FabricVersion {
+	parsed, err := parseFabricSemanticVersion(version, false)
+	if err != nil {
+		return &FabricVersion{Raw: version}
+	}
+	return parsed
+}
+
+// parseFabricSemanticVersion parses a SemVer-like version, allowing wildcard components when used in a predicate
+func parseFabricSemanticVersion(version string, allowWildcard bool) (*FabricVersion, error) {
+	parsed := &FabricVersion{Raw: version, Semantic: true}
+
+	rest, build, hasBuild := strings.Cut(version, "+")
+	if hasBuild {
+		if !fabricBuildPattern.MatchString(build) {
+			return nil, fmt.Errorf("invalid build metadata in %q", version)
+		}
+		parsed.Build = build
+	}
+	rest, prerelease, hasPrerelease := strings.Cut(rest, "-")
+	if hasPrerelease {
+		if !fabricPrereleasePattern.MatchString(prerelease) {
+			return nil, fmt.Errorf("invalid pre-release in %q", version)
+		}
+		parsed.Prerelease = prerelease
+		parsed.prerelease = true
+	}
+
+	if rest == "" {
+		return nil, fmt.Errorf("missing version number in %q", version)
+	}
+	for _, part := range strings.Split(rest, ".") {
+		if parsed.hasWildcard() {
+			return nil, fmt.Errorf("%w: it must be the last component in %q", errFabricWildcard, version)
+		}
+		if allowWildcard && (part == "x" || part == "X" || part == "*") {
+			parsed.Components = append(parsed.Components, -1)
+			continue
+		}
+		number, err := strconv.Atoi(part)
+		if err != nil || number < 0 || part == "" || part[0] == '+' {
+			return nil, fmt.Errorf("invalid version component %q in %q", part, version)
+		}
+		parsed.Components = append(parsed.Components, number)
+	}
+	if parsed.hasWildcard() && hasPrerelease {
+		return nil, fmt.Errorf("%w: wildcard versions cannot have a pre-release in %q", errFabricWildcard, version)
+	}
+	return parsed, nil
+}
+
+// component returns the n-th numeric component, treating missing components as 0
+func (v *FabricVersion) component(n int) int {
+	if n < len(v.Components) && v.Components[n] >= 0 {
+		return v.Components[n]
+	}
+	return 0
+}
+
+// hasWildcard reports whether the version ends in an x/X/* component
+func (v *FabricVersion) hasWildcard() bool {
+	return len(v.Components) > 0 && v.Components[len(v.Components)-1] == -1
+}
+
+func (v *FabricVersion) String() string {
+	return v.Raw
+}
+
+// Compare orders two versions, returning -1, 0 or 1. Build metadata is ignored.
+// Versions that are not SemVer can only be compared by their text.
+func (v *FabricVersion) Compare(other *FabricVersion) int {
+	if !v.Semantic || !other.Semantic {
+		return strings.Compare(v.Raw, other.Raw)
+	}
+
+	length := max(len(v.Components), len(other.Components))
+	for i := 0; i < length; i++ {
+		if a, b := v.component(i), other.component(i); a != b {
+			if a < b {
+				return -1
+			}
+			return 1
+		}
+	}
+
+	// A release sorts after its pre-releases, and an empty pre-release before every other one
+	switch {
+	case !v.prerelease && !other.prerelease:
+		return 0
+	case !v.prerelease:
+		return 1
+	case !other.prerelease:
+		return -1
+	case v.Prerelease == other.Prerelease:
+		return 0
+	case v.Prerelease == "":
+		return -1
+	case other.Prerelease == "":
+		return 1
+	}
+
+	a, b := strings.Split(v.Prerelease, "."), strings.Split(other.Prerelease, ".")
+	for i := 0; i < min(len(a), len(b)); i++ {
+		if cmp := comparePrereleasePart(a[i], b[i]); cmp != 0 {
+			return cmp
+		}
+	}
+	switch {
+	case len(a) < len(b):
+		return -1
+	case len(a) > len(b):
+		return 1
+	}
+	return 0
+}
+
+// comparePrereleasePart compares SemVer pre-release identifiers: numbers numerically and before text
+func comparePrereleasePart(a string, b string) int {
+	aNum, aErr := strconv.Atoi(a)
+	bNum, bErr := strconv.Atoi(b)
+	switch {
+	case aErr == nil && bErr == nil:
+		if aNum < bNum {
+			return -1
+		} else if aNum > bNum {
+			return 1
+		}
+		return 0
+	case aErr == nil:
+		return -1
+	case bErr == nil:
+		return 1
+	}
+	return strings.Compare(a, b)
+}
+
+// ParseFabricVersionPredicate parses a fabric.mod.json dependency predicate such as ">=1.20 <1.21", "~1.2.3",
+// "^0.14", "1.20.x" or "*". Alternatives may be separated by ||.
+func ParseFabricVersionPredicate(predicate string) (*FabricVersionPredicate, error) {
+	parsed := &FabricVersionPredicate{}
+	for _, alternative := range strings.Split(predicate, "||") {
+		terms := make([]fabricPredicateTerm, 0)
+		for _, field := range strings.Fields(alternative) {
+			term, err := parseFabricPredicateTerm(field)
+			if err != nil {
+				return nil, err
+			}
+			if term != nil {
+				terms = append(terms, *term)
+			}
+		}
+		parsed.alternatives = append(parsed.alternatives, terms)
+	}
+	return parsed, nil
+}
+
+// ParseFabricDependency parses a fabric.mod.json dependency value, which is a predicate string or a list of them
+func ParseFabricDependency(entry any) (*FabricVersionPredicate, error) {
+	switch value := entry.(type) {
+	case string:
+		return ParseFabricVersionPredicate(value)
+	case []string:
+		return ParseFabricVersionPredicate(strings.Join(value, " || "))
+	case []any:
+		parts := make([]string, 0, len(value))
+		for _, part := range value {
+			str, ok := part.(string)
+			if !ok {
+				return nil, fmt.Errorf("invalid dependency predicate %v", part)
+			}
+			parts = append(parts, str)
+		}
+		return ParseFabricVersionPredicate(strings.Join(parts, " || "))
+	}
+	return nil, fmt.Errorf("invalid dependency predicate %v", entry)
+}
+
+// parseFabricPredicateTerm parses a single operator and version, returning nil for * which matches anything
+func parseFabricPredicateTerm(term string) (*fabricPredicateTerm, error) {
+	if term == "*" {
+		return nil, nil
+	}
+
+	operator := "="
+	for _, candidate := range fabricOperators {
+		if strings.HasPrefix(term, candidate) {
+			operator = candidate
+			term = strings.TrimSpace(term[len(candidate):])
+			break
+		}
+	}
+
+	version, err := parseFabricSemanticVersion(term, true)
+	if err != nil {
+		if errors.Is(err, errFabricWildcard) {
+			return nil, err
+		}
+		if operator != "=" {
+			return nil, fmt.Errorf("operator %s requires a semantic version: %w", operator, err)
+		}
+		// Non-semantic versions can still be matched exactly
+		return &fabricPredicateTerm{operator: operator, version: &FabricVersion{Raw: term}}, nil
+	}
+	if version.hasWildcard() && operator != "=" {
+		return nil, fmt.Errorf("operator %s cannot be used with wildcard version %q", operator, term)
+	}
+	if len(version.Components) == 1 && version.Components[0] == -1 {
+		return nil, nil
+	}
+	return &fabricPredicateTerm{operator: operator, version: version}, nil
+}
+
+// Test reports whether the version satisfies the predicate
+func (p *FabricVersionPredicate) Test(version string) bool {
+	parsed := ParseFabricVersion(version)
+	for _, terms := range p.alternatives {
+		matched := true
+		for _, term := range terms {
+			if !term.test(parsed) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return true
+		}
+	}
+	return false
+}
+
+// test reports whether the version satisfies a single term
+func (t fabricPredicateTerm) test(version *FabricVersion) bool {
+	target := t.version
+	if !target.Semantic || !version.Semantic {
+		return t.operator == "=" && target.Raw == version.Raw
+	}
+
+	if target.hasWildcard() {
+		// 1.20.x matches every version starting with 1.20
+		for i := 0; i < len(target.Components)-1; i++ {
+			if version.component(i) != target.Components[i] {
+				return false
+			}
+		}
+		return true
+	}
+
+	cmp := version.Compare(target)
+	switch t.operator {
+	case ">=":
+		return cmp >= 0
+	case "<=":
+		return cmp <= 0
+	case ">":
+		return cmp > 0
+	case "<":
+		return cmp < 0
+	case "~":
+		return cmp >= 0 && version.component(0) == target.component(0) && version.component(1) == target.component(1)
+	case "^":
+		return cmp >= 0 && version.component(0) == target.component(0)
+	}
+	return cmp == 0
+}
+
+// FabricDependencySatisfied reports whether a version satisfies a fabric.mod.json dependency value
+func FabricDependencySatisfied(entry any, version string) (bool, error) {
+	predicate, err := ParseFabricDependency(entry)
+	if err != nil {
+		return false, err
+	}
+	return predicate.Test(version), nil
+}
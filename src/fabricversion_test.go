@@ -0,0 +1,77 @@
+package mcmodmeta_test
+
+import (
+	mcmodmeta "mc-mod-metadata/src"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFabricVersionCompare(t *testing.T) {
+	ordered := []string{"0.14.9", "1.0.0-", "1.0.0-alpha", "1.0.0-alpha.1", "1.0.0-alpha.beta", "1.0.0-beta.2", "1.0.0-beta.11", "1.0.0-rc.1", "1.0.0", "1.20", "1.20.1", "1.21"}
+	for i := 1; i < len(ordered); i++ {
+		a, b := mcmodmeta.ParseFabricVersion(ordered[i-1]), mcmodmeta.ParseFabricVersion(ordered[i])
+		assert.Equal(t, -1, a.Compare(b), "%s < %s", a, b)
+		assert.Equal(t, 1, b.Compare(a), "%s > %s", b, a)
+	}
+
+	assert.Equal(t, 0, mcmodmeta.ParseFabricVersion("1.20").Compare(mcmodmeta.ParseFabricVersion("1.20.0+build.5")))
+	assert.True(t, mcmodmeta.ParseFabricVersion("1.20.1").Semantic)
+	assert.False(t, mcmodmeta.ParseFabricVersion("23w31a").Semantic)
+}
+
+func TestFabricVersionPredicates(t *testing.T) {
+	cases := []struct {
+		predicate string
+		version   string
+		expected  bool
+	}{
+		{"*", "23w31a", true},
+		{">=1.20 <1.21", "1.20.4", true},
+		{">=1.20 <1.21", "1.21", false},
+		{">=1.20 <1.21", "1.19.4", false},
+		{"~1.2.3", "1.2.9", true},
+		{"~1.2.3", "1.3.0", false},
+		{"~1.2.3", "1.2.2", false},
+		{"^0.14", "0.15.3", true},
+		{"^0.14", "1.0.0", false},
+		{"1.20.x", "1.20.6", true},
+		{"1.20.x", "1.21", false},
+		{"1.x", "1.21.1", true},
+		{"1.20.1", "1.20.1", true},
+		{"=1.20.1", "1.20.2", false},
+		{">1.20.1", "1.20.1", false},
+		{"<=1.20.1", "1.20.1", true},
+		{">=0.15.0", "0.15.0-beta.3", false},
+		{"1.19.4 || 1.20.1", "1.20.1", true},
+		{"1.19.4 || 1.20.1", "1.20", false},
+		{"23w31a", "23w31a", true},
+		{"23w31a", "23w32a", false},
+		{">=1.21-", "1.21-rc1", true},
+		{">=1.21-", "1.21", true},
+		{">=1.21-", "1.20.6", false},
+	}
+	for _, c := range cases {
+		predicate, err := mcmodmeta.ParseFabricVersionPredicate(c.predicate)
+		assert.Nil(t, err, c.predicate)
+		assert.Equal(t, c.expected, predicate.Test(c.version), "%q against %q", c.version, c.predicate)
+	}
+
+	for _, invalid := range []string{">=23w31a", ">=1.20.x", "1.20.x.x", "1.x.0", "1.20.x-beta"} {
+		_, err := mcmodmeta.ParseFabricVersionPredicate(invalid)
+		assert.NotNil(t, err, invalid)
+	}
+}
+
+func TestFabricDependencySatisfied(t *testing.T) {
+	satisfied, err := mcmodmeta.FabricDependencySatisfied([]any{"1.19.x", ">=1.20.2"}, "1.20.4")
+	assert.Nil(t, err)
+	assert.True(t, satisfied)
+
+	satisfied, err = mcmodmeta.FabricDependencySatisfied([]any{"1.19.x", ">=1.20.2"}, "1.20.1")
+	assert.Nil(t, err)
+	assert.False(t, satisfied)
+
+	_, err = mcmodmeta.FabricDependencySatisfied(42.0, "1.20.1")
+	assert.NotNil(t, err)
+}
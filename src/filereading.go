@@ -2,10 +2,13 @@ package mcmodmeta
 
 import (
 	"archive/zip"
+	"bytes"
 	"errors"
 	"fmt"
 	"io"
-	"log"
+	"os"
+	"path/filepath"
+	"strings"
 )
 
 func stringFromFile(file *zip.File) (string, error) {
@@ -22,137 +25,472 @@ func stringFromFile(file *zip.File) (string, error) {
 	return string(fileData), nil
 }
 
-func readZipPart(file *zip.File) (string, error) {
+// jarScanner holds the state for reading a single jar
+type jarScanner struct {
+	options readOptions
+	jar     *JarMetadata
+	depth   int // How many jars this one is nested in, 0 for a jar read directly
+}
+
+// jsonFromFile reads a JSON descriptor, normalising it first when lenient decoding is enabled
+func (s *jarScanner) jsonFromFile(file *zip.File) (string, error) {
+	fileStr, err := stringFromFile(file)
+	if err != nil || !s.options.lenientJSON {
+		return fileStr, err
+	}
+
+	normalized, warnings := NormalizeJSON([]byte(fileStr))
+	for _, warning := range warnings {
+		s.warn(file.Name, warning)
+	}
+	return string(normalized), nil
+}
+
+// warn records a non-fatal problem with a file in the jar
+func (s *jarScanner) warn(path string, message string) {
+	s.jar.Warnings = append(s.jar.Warnings, Warning{Path: path, Message: message})
+}
+
+// readZipPart parses a single zip entry if it is a known descriptor.
+// A descriptor declaring several mods may return the valid ones alongside an error for the rest.
+func (s *jarScanner) readZipPart(file *zip.File) ([]*ModMetadata, error) {
 	switch {
 	case file.Name == "plugin.yml":
 		{
 			fileStr, err := stringFromFile(file)
 			if err != nil {
-				return "", err
+				return nil, &MalformedDescriptorError{Path: file.Name, Err: err}
 			}
 
 			plugin, err := NewBukkitPlugin(fileStr)
 			if err != nil {
-				return "", err
+				return nil, &MalformedDescriptorError{Path: file.Name, Err: err}
 			}
-			return plugin.Name, nil
+			if plugin.Name == "" {
+				return nil, &MissingFieldError{Path: file.Name, Field: "name"}
+			}
+			if plugin.Version == "" {
+				return nil, &MissingFieldError{Path: file.Name, Field: "version"}
+			}
+			if plugin.Main == "" {
+				return nil, &MissingFieldError{Path: file.Name, Field: "main"}
+			}
+			return []*ModMetadata{newBukkitMetadata(plugin, file.Name)}, nil
+		}
+	case file.Name == "paper-plugin.yml":
+		{
+			fileStr, err := stringFromFile(file)
+			if err != nil {
+				return nil, &MalformedDescriptorError{Path: file.Name, Err: err}
+			}
+
+			plugin, err := NewPaperPlugin(fileStr)
+			if err != nil {
+				return nil, &MalformedDescriptorError{Path: file.Name, Err: err}
+			}
+			if plugin.Name == "" {
+				return nil, &MissingFieldError{Path: file.Name, Field: "name"}
+			}
+			if plugin.Version == "" {
+				return nil, &MissingFieldError{Path: file.Name, Field: "version"}
+			}
+			if plugin.Main == "" {
+				return nil, &MissingFieldError{Path: file.Name, Field: "main"}
+			}
+			if plugin.APIVersion == "" {
+				return nil, &MissingFieldError{Path: file.Name, Field: "api-version"}
+			}
+			return []*ModMetadata{newPaperMetadata(plugin, file.Name)}, nil
 		}
 	case file.Name == "bungee.yml":
 		{
 			fileStr, err := stringFromFile(file)
 			if err != nil {
-				return "", err
+				return nil, &MalformedDescriptorError{Path: file.Name, Err: err}
 			}
 
 			plugin, err := NewBungeeCordPlugin(fileStr)
 			if err != nil {
-				return "", err
+				return nil, &MalformedDescriptorError{Path: file.Name, Err: err}
+			}
+			if plugin.Name == "" {
+				return nil, &MissingFieldError{Path: file.Name, Field: "name"}
 			}
-			return plugin.Name, nil
+			if plugin.Main == "" {
+				return nil, &MissingFieldError{Path: file.Name, Field: "main"}
+			}
+			return []*ModMetadata{newBungeeCordMetadata(plugin, file.Name)}, nil
 		}
 	case file.Name == "fabric.mod.json":
 		{
-			fileStr, err := stringFromFile(file)
+			fileStr, err := s.jsonFromFile(file)
 			if err != nil {
-				return "", err
+				return nil, &MalformedDescriptorError{Path: file.Name, Err: err}
 			}
 
 			plugin, err := NewFabricMod(fileStr)
 			if err != nil {
-				fmt.Println(err)
-				return "", err
+				return nil, &MalformedDescriptorError{Path: file.Name, Err: err}
+			}
+			if plugin.ID == "" {
+				return nil, &MissingFieldError{Path: file.Name, Field: "id"}
+			}
+			if plugin.Version == "" {
+				return nil, &MissingFieldError{Path: file.Name, Field: "version"}
+			}
+			return []*ModMetadata{newFabricMetadata(plugin, file.Name)}, nil
+		}
+	case file.Name == "quilt.mod.json":
+		{
+			fileStr, err := s.jsonFromFile(file)
+			if err != nil {
+				return nil, &MalformedDescriptorError{Path: file.Name, Err: err}
 			}
-			return plugin.ID, nil
+
+			plugin, err := NewQuiltMod(fileStr)
+			if err != nil {
+				return nil, &MalformedDescriptorError{Path: file.Name, Err: err}
+			}
+			if plugin.QuiltLoader.ID == "" {
+				return nil, &MissingFieldError{Path: file.Name, Field: "quilt_loader.id"}
+			}
+			if plugin.QuiltLoader.Version == "" {
+				return nil, &MissingFieldError{Path: file.Name, Field: "quilt_loader.version"}
+			}
+			return []*ModMetadata{newQuiltMetadata(plugin, file.Name)}, nil
 		}
 	case file.Name == "mcmod.info":
 		{
-			fileStr, err := stringFromFile(file)
+			fileStr, err := s.jsonFromFile(file)
 			if err != nil {
-				return "", err
+				return nil, &MalformedDescriptorError{Path: file.Name, Err: err}
 			}
 
-			plugin, err := NewForgeMod(fileStr)
+			plugin, err := NewForgeLegacyMod(fileStr)
 			if err != nil {
-				return "", err
+				return nil, &MalformedDescriptorError{Path: file.Name, Err: err}
+			}
+
+			if len(plugin) == 0 {
+				return nil, &MissingFieldError{Path: file.Name, Field: "modid"}
 			}
-			return plugin.Mods[0].ModID, nil
+
+			mods := make([]*ModMetadata, 0, len(plugin))
+			for _, mod := range plugin {
+				if mod.ModID == "" {
+					err = &MissingFieldError{Path: file.Name, Field: "modid"}
+					continue
+				}
+				mods = append(mods, newForgeLegacyMetadata(mod, file.Name))
+			}
+			return mods, err
 		}
 	case file.Name == "META-INF/mods.toml":
 		{
 			fileStr, err := stringFromFile(file)
 			if err != nil {
-				return "", err
+				return nil, &MalformedDescriptorError{Path: file.Name, Err: err}
 			}
 
 			plugin, err := NewForgeMod(fileStr)
 			if err != nil {
-				return "", err
+				return nil, &MalformedDescriptorError{Path: file.Name, Err: err}
+			}
+			if len(plugin.Mods) == 0 {
+				return nil, &MissingFieldError{Path: file.Name, Field: "mods"}
+			}
+
+			mods := make([]*ModMetadata, 0, len(plugin.Mods))
+			for _, info := range plugin.Mods {
+				if info.ModID == "" {
+					err = &MissingFieldError{Path: file.Name, Field: "modId"}
+					continue
+				}
+				mods = append(mods, newForgeMetadata(plugin, info, file.Name))
 			}
-			return plugin.Mods[0].ModID, nil
+			return mods, err
 		}
 	case file.Name == "META-INF/neoforge.mods.toml":
 		{
 			fileStr, err := stringFromFile(file)
 			if err != nil {
-				return "", err
+				return nil, &MalformedDescriptorError{Path: file.Name, Err: err}
 			}
 
 			plugin, err := NewNeoForgeMod(fileStr)
 			if err != nil {
-				return "", err
+				return nil, &MalformedDescriptorError{Path: file.Name, Err: err}
+			}
+			if len(plugin.Mods) == 0 {
+				return nil, &MissingFieldError{Path: file.Name, Field: "mods"}
 			}
 
-			return plugin.Mods[0].ModID, nil
+			mods := make([]*ModMetadata, 0, len(plugin.Mods))
+			for _, info := range plugin.Mods {
+				if info.ModID == "" {
+					err = &MissingFieldError{Path: file.Name, Field: "modId"}
+					continue
+				}
+				mods = append(mods, newNeoForgeMetadata(plugin, info, file.Name))
+			}
+			return mods, err
 		}
 	case file.Name == "META-INF/sponge_plugins.json":
 		{
-			fileStr, err := stringFromFile(file)
+			fileStr, err := s.jsonFromFile(file)
 			if err != nil {
-				return "", err
+				return nil, &MalformedDescriptorError{Path: file.Name, Err: err}
 			}
 
 			plugin, err := NewSpongePlugin(fileStr)
 			if err != nil {
-				return "", err
+				return nil, &MalformedDescriptorError{Path: file.Name, Err: err}
 			}
-			return plugin.Plugins[0].ID, nil
+			if len(plugin.Plugins) == 0 {
+				return nil, &MissingFieldError{Path: file.Name, Field: "plugins"}
+			}
+
+			mods := make([]*ModMetadata, 0, len(plugin.Plugins))
+			for _, info := range plugin.Plugins {
+				if info.ID == "" {
+					err = &MissingFieldError{Path: file.Name, Field: "id"}
+					continue
+				}
+				mods = append(mods, newSpongeMetadata(plugin, info, file.Name))
+			}
+			return mods, err
 		}
 	case file.Name == "velocity-plugin.json":
 		{
-			fileStr, err := stringFromFile(file)
+			fileStr, err := s.jsonFromFile(file)
 			if err != nil {
-				return "", err
+				return nil, &MalformedDescriptorError{Path: file.Name, Err: err}
 			}
 
 			plugin, err := NewVelocityPlugin(fileStr)
 			if err != nil {
-				return "", err
+				return nil, &MalformedDescriptorError{Path: file.Name, Err: err}
 			}
-			return plugin.ID, nil
+			if plugin.ID == "" {
+				return nil, &MissingFieldError{Path: file.Name, Field: "id"}
+			}
+			return []*ModMetadata{newVelocityMetadata(plugin, file.Name)}, nil
 		}
 	default:
 		{
-			return "", errors.New("unknown file")
+			return nil, ErrUnsupportedDescriptor
 		}
 	}
 }
 
-func ReadJarFile(file string) ([]string, error) {
-	zipListing, err := zip.OpenReader(file)
+// ReadJarFile reads every known descriptor in a jar and returns the mods it declares for each platform.
+// Descriptors that fail to parse are reported in JarMetadata.Errors rather than failing the whole jar;
+// the returned error is only set when the jar itself cannot be read, and wraps ErrNotJar if it is not a zip.
+func ReadJarFile(file string, opts ...Option) (*JarMetadata, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	return ReadJar(f, info.Size(), file, opts...)
+}
+
+// ReadJar reads a jar of the given size from r, like ReadJarFile. path only labels the result and its errors.
+// Jars nested inside it, listed by fabric.mod.json, quilt.mod.json or META-INF/jarjar/metadata.json,
+// are read in memory and returned as JarMetadata.Children.
+func ReadJar(r io.ReaderAt, size int64, path string, opts ...Option) (*JarMetadata, error) {
+	return readJar(r, size, path, newReadOptions(opts), 0)
+}
+
+// newJarMetadata returns an empty JarMetadata for the jar at path, with every list allocated
+func newJarMetadata(path string) *JarMetadata {
+	return &JarMetadata{
+		Path:           path,
+		Mods:           make([]*ModMetadata, 0),
+		ModAnnotations: make([]*ForgeModAnnotation, 0),
+		Children:       make([]*JarMetadata, 0),
+		Errors:         make([]error, 0),
+		Warnings:       make([]Warning, 0),
+	}
+}
+
+// readJar reads a single jar and, recursively, the jars nested inside it
+func readJar(r io.ReaderAt, size int64, path string, options readOptions, depth int) (*JarMetadata, error) {
+	zipListing, err := zip.NewReader(r, size)
 	if err != nil {
-		log.Fatal(err)
+		if errors.Is(err, zip.ErrFormat) || errors.Is(err, zip.ErrAlgorithm) || errors.Is(err, zip.ErrChecksum) {
+			return nil, fmt.Errorf("%s: %w: %w", path, ErrNotJar, err)
+		}
+		return nil, err
 	}
-	defer zipListing.Close()
 
-	mods := make([]string, 0)
+	jar := newJarMetadata(path)
+	scanner := &jarScanner{options: options, jar: jar, depth: depth}
 	for _, file := range zipListing.File {
-		name, err := readZipPart(file)
+		switch file.Name {
+		case "META-INF/MANIFEST.MF":
+			manifestStr, err := stringFromFile(file)
+			if err != nil {
+				jar.Errors = append(jar.Errors, &MalformedDescriptorError{Path: file.Name, Err: err})
+				continue
+			}
+
+			manifest, err := NewManifest(manifestStr)
+			if err != nil {
+				jar.Errors = append(jar.Errors, &MalformedDescriptorError{Path: file.Name, Err: err})
+				continue
+			}
+			jar.Manifest = manifest
+		case "META-INF/jarjar/metadata.json":
+			metadataStr, err := scanner.jsonFromFile(file)
+			if err != nil {
+				jar.Errors = append(jar.Errors, &MalformedDescriptorError{Path: file.Name, Err: err})
+				continue
+			}
+
+			metadata, err := NewJarJarMetadata(metadataStr)
+			if err != nil {
+				jar.Errors = append(jar.Errors, &MalformedDescriptorError{Path: file.Name, Err: err})
+				continue
+			}
+			jar.JarJar = metadata
+		}
+	}
+
+	placeholders := fmlPlaceholders(jar.Manifest, scanner.options.placeholders)
+	for _, file := range zipListing.File {
+		mods, err := scanner.readZipPart(file)
+		if errors.Is(err, ErrUnsupportedDescriptor) {
+			continue
+		} else if err != nil {
+			jar.Errors = append(jar.Errors, err)
+		}
+		jar.Mods = append(jar.Mods, mods...)
+	}
+
+	// @Mod annotations fill in mcmod.info before placeholders are resolved, so a ${version} the
+	// annotation replaces is not reported as unresolved
+	if needsModAnnotations(zipListing, jar) {
+		jar.Mods = append(jar.Mods, scanner.readModAnnotations(zipListing, placeholders)...)
+	}
+	for _, mod := range jar.Mods {
+		scanner.resolvePlaceholders(mod, placeholders)
+	}
+
+	scanner.readClassVersions(zipListing)
+	jar.Java.Declared = declaredJavaRequirements(jar.Mods)
+
+	for _, nested := range scanner.nestedJarPaths() {
+		child, err := scanner.readNestedJar(zipListing, nested)
 		if err != nil {
+			scanner.warn(nested, err.Error())
+			continue
+		}
+		jar.Children = append(jar.Children, child)
+	}
+
+	return jar, nil
+}
+
+// nestedJarPaths lists every jar the descriptors say is embedded, in the order they are declared
+func (s *jarScanner) nestedJarPaths() []string {
+	paths := make([]string, 0)
+	for _, mod := range s.jar.Mods {
+		switch raw := mod.Raw.(type) {
+		case *FabricMod:
+			for _, nested := range raw.Jars {
+				paths = append(paths, nested.File)
+			}
+		case *QuiltMod:
+			paths = append(paths, raw.QuiltLoader.Jars...)
+		}
+	}
+	if s.jar.JarJar != nil {
+		for _, nested := range s.jar.JarJar.Jars {
+			paths = append(paths, nested.Path)
+		}
+	}
+	return mergeLists(paths)
+}
+
+// readNestedJar reads an embedded jar into memory and scans it. A nested jar that is not a zip is
+// still returned, with the failure as its only entry in JarMetadata.Errors. Jars past the nesting depth
+// or size limits are not read.
+func (s *jarScanner) readNestedJar(zipListing *zip.Reader, name string) (*JarMetadata, error) {
+	path := s.jar.Path + "!/" + name
+	for _, file := range zipListing.File {
+		if file.Name != strings.TrimPrefix(name, "/") {
 			continue
-		} else {
-			mods = append(mods, name)
 		}
+
+		if s.depth >= s.options.maxNestingDepth {
+			return nil, fmt.Errorf("nested jar is not read, it is more than %d jars deep", s.options.maxNestingDepth)
+		}
+		contents, err := s.nestedJarContents(file)
+		if err != nil {
+			return nil, err
+		}
+		child, err := readJar(bytes.NewReader(contents), int64(len(contents)), path, s.options, s.depth+1)
+		if err != nil {
+			child = newJarMetadata(path)
+			child.Errors = []error{err}
+		}
+		return child, nil
+	}
+	return nil, errors.New("nested jar is declared but missing")
+}
+
+// nestedJarContents reads a nested jar into memory, refusing one larger than the size limit. The size in
+// the zip entry's header is checked first, and the data itself as it is read, since the header may lie.
+func (s *jarScanner) nestedJarContents(file *zip.File) ([]byte, error) {
+	tooLarge := fmt.Errorf("nested jar is not read, it is larger than %d bytes", s.options.maxNestedSize)
+	if file.UncompressedSize64 > uint64(s.options.maxNestedSize) {
+		return nil, tooLarge
 	}
 
-	return mods, nil
+	fileReader, err := file.Open()
+	if err != nil {
+		return nil, fmt.Errorf("nested jar could not be read: %w", err)
+	}
+	defer fileReader.Close()
+
+	contents, err := io.ReadAll(io.LimitReader(fileReader, s.options.maxNestedSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("nested jar could not be read: %w", err)
+	}
+	if int64(len(contents)) > s.options.maxNestedSize {
+		return nil, tooLarge
+	}
+	return contents, nil
+}
+
+// ReadJarDir reads every .jar file directly inside dir, in name order.
+// A jar that cannot be read is still returned, with the failure as its only entry in JarMetadata.Errors;
+// the returned error is only set when the directory itself cannot be listed.
+func ReadJarDir(dir string, opts ...Option) ([]*JarMetadata, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	jars := make([]*JarMetadata, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.EqualFold(filepath.Ext(entry.Name()), ".jar") {
+			continue
+		}
+
+		path := filepath.Join(dir, entry.Name())
+		jar, err := ReadJarFile(path, opts...)
+		if err != nil {
+			jar = newJarMetadata(path)
+			jar.Errors = []error{err}
+		}
+		jars = append(jars, jar)
+	}
+	return jars, nil
 }
@@ -0,0 +1,563 @@
+package mcmodmeta_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	mcmodmeta "mc-mod-metadata/src"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// writeTestJar writes a jar containing the given entries to a temporary directory
+func writeTestJar(t *testing.T, entries map[string]string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "test.jar")
+	out, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer out.Close()
+
+	names := make([]string, 0, len(entries))
+	for name := range entries {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	writer := zip.NewWriter(out)
+	for _, name := range names {
+		entry, err := writer.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := entry.Write([]byte(entries[name])); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestReadJarFileMetadata(t *testing.T) {
+	jar := writeTestJar(t, map[string]string{
+		"fabric.mod.json": `{
+  "schemaVersion": 1,
+  "id": "taterlib",
+  "version": "0.1.0",
+  "name": "TaterLib",
+  "description": "some words",
+  "authors": ["p0t4t0sandwich"],
+  "license": "GPL-3.0",
+  "environment": "*",
+  "contact": {"homepage": "https://some.homepage", "sources": "https://some.repo"},
+  "depends": {"fabricloader": ">=0.9.0", "minecraft": ["1.20.1", "1.20.2"]},
+  "breaks": {"othermod": "*"}
+}`,
+		"velocity-plugin.json":     `{"id": "taterlib", "name": "TaterLib", "version": "0.1.0", "dependencies": [{"id": "luckperms", "optional": true}]}`,
+		"assets/taterlib/icon.png": "",
+	})
+
+	result, err := mcmodmeta.ReadJarFile(jar)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(result.Mods))
+
+	fabric := result.Mods[0]
+	assert.Equal(t, mcmodmeta.PlatformFabric, fabric.Platform)
+	assert.Equal(t, "fabric.mod.json", fabric.Source)
+	assert.Equal(t, "taterlib", fabric.ID)
+	assert.Equal(t, "TaterLib", fabric.Name)
+	assert.Equal(t, "0.1.0", fabric.Version)
+	assert.Equal(t, []string{"p0t4t0sandwich"}, fabric.Authors)
+	assert.Equal(t, "GPL-3.0", fabric.License)
+	assert.Equal(t, "https://some.homepage", fabric.Links.Homepage)
+	assert.Equal(t, "https://some.repo", fabric.Links.Source)
+	assert.Equal(t, mcmodmeta.SideBoth, fabric.Side)
+	assert.Equal(t, []mcmodmeta.ModDependency{
+		{ID: "fabricloader", VersionRange: ">=0.9.0", Kind: mcmodmeta.DependencyRequired},
+		{ID: "minecraft", VersionRange: "1.20.1 || 1.20.2", Kind: mcmodmeta.DependencyRequired},
+		{ID: "othermod", VersionRange: "*", Kind: mcmodmeta.DependencyIncompatible},
+	}, fabric.Dependencies)
+	assert.Equal(t, "taterlib", fabric.Raw.(*mcmodmeta.FabricMod).ID)
+
+	velocity := result.Mods[1]
+	assert.Equal(t, mcmodmeta.PlatformVelocity, velocity.Platform)
+	assert.Equal(t, mcmodmeta.SideServer, velocity.Side)
+	assert.Equal(t, mcmodmeta.DependencyOptional, velocity.Dependencies[0].Kind)
+}
+
+func TestReadJarFileMultiPlatform(t *testing.T) {
+	jar := writeTestJar(t, map[string]string{
+		"plugin.yml":                   "name: TaterLib\nversion: 0.1.0\nmain: dev.neuralnexus.taterloader.platforms.BukkitLoaderPlugin\n",
+		"bungee.yml":                   "name: TaterLib\nversion: 0.1.0\nmain: dev.neuralnexus.taterloader.platforms.BungeeCordLoaderPlugin\n",
+		"fabric.mod.json":              `{"schemaVersion": 1, "id": "taterlib", "version": "0.1.0"}`,
+		"META-INF/mods.toml":           "modLoader = \"javafml\"\nloaderVersion = \"[1,)\"\nlicense = \"GPL-3.0\"\n[[mods]]\nmodId = \"taterlib\"\n",
+		"META-INF/neoforge.mods.toml":  "modLoader = \"javafml\"\nloaderVersion = \"[1,)\"\nlicense = \"GPL-3.0\"\n[[mods]]\nmodId = \"taterlib\"\n",
+		"META-INF/sponge_plugins.json": `{"loader": {"name": "java_plain", "version": "1.0"}, "license": "GPL-3.0", "plugins": [{"id": "taterlib"}]}`,
+		"velocity-plugin.json":         `{"id": "taterlib", "name": "TaterLib", "version": "0.1.0"}`,
+	})
+
+	result, err := mcmodmeta.ReadJarFile(jar)
+
+	assert.Nil(t, err)
+	assert.Equal(t, jar, result.Path)
+	assert.ElementsMatch(t, []mcmodmeta.Platform{
+		mcmodmeta.PlatformBukkit,
+		mcmodmeta.PlatformBungeeCord,
+		mcmodmeta.PlatformFabric,
+		mcmodmeta.PlatformForge,
+		mcmodmeta.PlatformNeoForge,
+		mcmodmeta.PlatformSponge,
+		mcmodmeta.PlatformVelocity,
+	}, result.Platforms())
+	assert.True(t, result.Supports(mcmodmeta.PlatformNeoForge))
+	assert.Equal(t, 1, len(result.ModsFor(mcmodmeta.PlatformBungeeCord)))
+	assert.Equal(t, "bungee.yml", result.ModsFor(mcmodmeta.PlatformBungeeCord)[0].Source)
+	assert.Equal(t, "META-INF/neoforge.mods.toml", result.ModsFor(mcmodmeta.PlatformNeoForge)[0].Source)
+}
+
+func TestReadJarFileErrors(t *testing.T) {
+	jar := writeTestJar(t, map[string]string{
+		"fabric.mod.json":      `{"schemaVersion": 1, "id": "taterlib",`,
+		"velocity-plugin.json": `{"name": "TaterLib"}`,
+		"plugin.yml":           "name: TaterLib\nversion: 0.1.0\nmain: dev.neuralnexus.taterloader.platforms.BukkitLoaderPlugin\n",
+	})
+
+	result, err := mcmodmeta.ReadJarFile(jar)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(result.Mods))
+	assert.Equal(t, 2, len(result.Errors))
+
+	var malformed *mcmodmeta.MalformedDescriptorError
+	assert.ErrorAs(t, result.Errors[0], &malformed)
+	assert.Equal(t, "fabric.mod.json", malformed.Path)
+	assert.NotNil(t, malformed.Err)
+	assert.ErrorIs(t, result.Errors[0], mcmodmeta.ErrMalformedDescriptor)
+
+	var missing *mcmodmeta.MissingFieldError
+	assert.ErrorAs(t, result.Errors[1], &missing)
+	assert.Equal(t, "velocity-plugin.json", missing.Path)
+	assert.Equal(t, "id", missing.Field)
+	assert.ErrorIs(t, result.Errors[1], mcmodmeta.ErrMissingField)
+}
+
+func TestReadJarFileNotAZip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "broken.jar")
+	if err := os.WriteFile(path, []byte("definitely not a zip"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := mcmodmeta.ReadJarFile(path)
+
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, mcmodmeta.ErrNotJar)
+
+	_, err = mcmodmeta.ReadJarFile(filepath.Join(t.TempDir(), "missing.jar"))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestReadJarFileForgeLegacy(t *testing.T) {
+	jar := writeTestJar(t, map[string]string{
+		"mcmod.info": `[{
+  "modid": "taterlib",
+  "name": "TaterLib",
+  "version": "0.1.0",
+  "mcversion": "1.12.2",
+  "authorList": ["p0t4t0sandwich"]
+}, {
+  "modid": "taterlibcore",
+  "name": "TaterLib Core",
+  "version": "0.1.0",
+  "requiredMods": ["taterlib"]
+}]`,
+	})
+
+	result, err := mcmodmeta.ReadJarFile(jar)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(result.Errors))
+	assert.Equal(t, 2, len(result.Mods))
+	assert.Equal(t, mcmodmeta.PlatformForge, result.Mods[0].Platform)
+	assert.Equal(t, "taterlib", result.Mods[0].ID)
+	assert.Equal(t, "mcmod.info", result.Mods[0].Source)
+	assert.Equal(t, []mcmodmeta.ModDependency{
+		{ID: "minecraft", VersionRange: "1.12.2", Kind: mcmodmeta.DependencyRequired},
+	}, result.Mods[0].Dependencies)
+	assert.Equal(t, "taterlibcore", result.Mods[1].ID)
+	assert.Equal(t, "taterlib", result.Mods[1].Dependencies[0].ID)
+}
+
+func TestReadJarFileLenientJSON(t *testing.T) {
+	jar := writeTestJar(t, map[string]string{
+		"fabric.mod.json": `{
+  "schemaVersion": 1,
+  "id": "taterlib", // mod id
+  "version": "0.1.0",
+}`,
+	})
+
+	strict, err := mcmodmeta.ReadJarFile(jar)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(strict.Mods))
+	assert.Equal(t, 1, len(strict.Errors))
+
+	lenient, err := mcmodmeta.ReadJarFile(jar, mcmodmeta.WithLenientJSON())
+
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(lenient.Errors))
+	assert.Equal(t, "taterlib", lenient.Mods[0].ID)
+	assert.Equal(t, []mcmodmeta.Warning{
+		{Path: "fabric.mod.json", Message: "removed comments"},
+		{Path: "fabric.mod.json", Message: "removed trailing commas"},
+	}, lenient.Warnings)
+}
+
+func TestReadJarFileMultiModDescriptors(t *testing.T) {
+	jar := writeTestJar(t, map[string]string{
+		"META-INF/mods.toml": `modLoader = "javafml"
+loaderVersion = "[47,)"
+license = "MIT"
+
+[[mods]]
+modId = "corelib"
+version = "1.0.0"
+displayName = "Core Lib"
+
+[[mods]]
+modId = "companion"
+version = "1.0.0"
+displayName = "Companion"
+
+[[dependencies.corelib]]
+modId = "forge"
+mandatory = true
+versionRange = "[47,)"
+ordering = "NONE"
+side = "BOTH"
+
+[[dependencies.companion]]
+modId = "corelib"
+mandatory = true
+versionRange = "[1.0.0,)"
+ordering = "AFTER"
+side = "BOTH"
+`,
+		"META-INF/neoforge.mods.toml": `modLoader = "javafml"
+loaderVersion = "[1,)"
+license = "MIT"
+mods = []
+`,
+		"META-INF/sponge_plugins.json": `{
+  "loader": {"name": "java_plain", "version": "1.0"},
+  "license": "MIT",
+  "global": {"version": "2.0.0"},
+  "plugins": [{"id": "first"}, {"id": "second", "version": "2.1.0"}, {"name": "no id"}]
+}`,
+	})
+
+	result, err := mcmodmeta.ReadJarFile(jar)
+
+	assert.Nil(t, err)
+
+	forge := result.ModsFor(mcmodmeta.PlatformForge)
+	assert.Equal(t, 2, len(forge))
+	assert.Equal(t, "corelib", forge[0].ID)
+	assert.Equal(t, "forge", forge[0].Dependencies[0].ID)
+	assert.Equal(t, "companion", forge[1].ID)
+	assert.Equal(t, 1, len(forge[1].Dependencies))
+	assert.Equal(t, "corelib", forge[1].Dependencies[0].ID)
+	assert.Equal(t, "AFTER", forge[1].Dependencies[0].Ordering)
+
+	sponge := result.ModsFor(mcmodmeta.PlatformSponge)
+	assert.Equal(t, 2, len(sponge))
+	assert.Equal(t, "2.0.0", sponge[0].Version)
+	assert.Equal(t, "2.1.0", sponge[1].Version)
+
+	assert.Equal(t, 0, len(result.ModsFor(mcmodmeta.PlatformNeoForge)))
+	assert.Equal(t, 2, len(result.Errors))
+	assert.Equal(t, &mcmodmeta.MissingFieldError{Path: "META-INF/neoforge.mods.toml", Field: "mods"}, result.Errors[0])
+	assert.Equal(t, &mcmodmeta.MissingFieldError{Path: "META-INF/sponge_plugins.json", Field: "id"}, result.Errors[1])
+}
+
+func TestReadJarFilePlaceholders(t *testing.T) {
+	jar := writeTestJar(t, map[string]string{
+		"META-INF/MANIFEST.MF": "Manifest-Version: 1.0\r\nImplementation-Title: taterlib\r\nImplementation-Version: 1.2.3+build.4\r\n\r\n",
+		"META-INF/neoforge.mods.toml": `modLoader = "javafml"
+loaderVersion = "[1,)"
+license = "MIT"
+
+[[mods]]
+modId = "taterlib"
+version = "${file.jarVersion}"
+
+[[dependencies.taterlib]]
+modId = "minecraft"
+type = "required"
+versionRange = "${minecraft_version_range}"
+
+[[dependencies.taterlib]]
+modId = "neoforge"
+type = "required"
+versionRange = "[${global.forgeVersion},)"
+`,
+	})
+
+	result, err := mcmodmeta.ReadJarFile(jar, mcmodmeta.WithPlaceholder("global.forgeVersion", "21.0.1"))
+
+	assert.Nil(t, err)
+	assert.Equal(t, "1.2.3+build.4", result.Mods[0].Version)
+	assert.Equal(t, "${minecraft_version_range}", result.Mods[0].Dependencies[0].VersionRange)
+	assert.Equal(t, "[21.0.1,)", result.Mods[0].Dependencies[1].VersionRange)
+	assert.Equal(t, []mcmodmeta.Warning{{
+		Path:    "META-INF/neoforge.mods.toml",
+		Message: "unresolved placeholder ${minecraft_version_range} in minecraft version range of taterlib",
+	}}, result.Warnings)
+
+	result, err = mcmodmeta.ReadJarFile(writeTestJar(t, map[string]string{
+		"META-INF/mods.toml": "modLoader = \"javafml\"\nloaderVersion = \"[47,)\"\nlicense = \"MIT\"\n[[mods]]\nmodId = \"taterlib\"\nversion = \"${file.jarVersion}\"\n",
+	}))
+
+	assert.Nil(t, err)
+	assert.Equal(t, "${file.jarVersion}", result.Mods[0].Version)
+	assert.Equal(t, "unresolved placeholder ${file.jarVersion} in version of taterlib", result.Warnings[0].Message)
+}
+
+func TestReadJarFileManifest(t *testing.T) {
+	jar := writeTestJar(t, map[string]string{
+		"META-INF/MANIFEST.MF": "Manifest-Version: 1.0\nMixinConfigs: taterlib.mixins.json\nFMLModType: LIBRARY\n",
+	})
+
+	result, err := mcmodmeta.ReadJarFile(jar)
+
+	assert.Nil(t, err)
+	assert.NotNil(t, result.Manifest)
+	assert.Equal(t, "LIBRARY", result.Manifest.FMLModType())
+	assert.Equal(t, []string{"taterlib.mixins.json"}, result.Manifest.MixinConfigs())
+
+	result, err = mcmodmeta.ReadJarFile(writeTestJar(t, map[string]string{"plugin.yml": "name: x\nversion: 1\nmain: x.X\n"}))
+
+	assert.Nil(t, err)
+	assert.Nil(t, result.Manifest)
+}
+
+func TestReadJarFileQuilt(t *testing.T) {
+	jar := writeTestJar(t, map[string]string{
+		"fabric.mod.json": `{"schemaVersion": 1, "id": "taterlib", "version": "0.1.0"}`,
+		"quilt.mod.json": `{
+  "schema_version": 1,
+  "quilt_loader": {
+    "id": "taterlib",
+    "version": "0.1.0",
+    "depends": [{"id": "minecraft", "versions": [">=1.20", "1.19.4"]}, [{"id": "a"}, {"id": "b"}]],
+    "metadata": {"name": "TaterLib", "license": [{"name": "MIT License", "id": "MIT"}, "Apache-2.0"]}
+  },
+  "minecraft": {"environment": "client"}
+}`,
+	})
+
+	result, err := mcmodmeta.ReadJarFile(jar)
+
+	assert.Nil(t, err)
+	assert.ElementsMatch(t, []mcmodmeta.Platform{mcmodmeta.PlatformFabric, mcmodmeta.PlatformQuilt}, result.Platforms())
+
+	quilt := result.ModsFor(mcmodmeta.PlatformQuilt)[0]
+	assert.Equal(t, "taterlib", quilt.ID)
+	assert.Equal(t, "TaterLib", quilt.Name)
+	assert.Equal(t, "MIT OR Apache-2.0", quilt.License)
+	assert.Equal(t, mcmodmeta.SideClient, quilt.Side)
+	assert.Equal(t, "quilt.mod.json", quilt.Source)
+	assert.Equal(t, []mcmodmeta.ModDependency{
+		{ID: "minecraft", VersionRange: ">=1.20 || 1.19.4", Kind: mcmodmeta.DependencyRequired},
+		{ID: "a", Kind: mcmodmeta.DependencyOptional},
+		{ID: "b", Kind: mcmodmeta.DependencyOptional},
+	}, quilt.Dependencies)
+}
+
+func TestReadJarFilePaperAndBukkit(t *testing.T) {
+	jar := writeTestJar(t, map[string]string{
+		"plugin.yml": "name: TaterLib\nversion: 0.1.0\nmain: dev.neuralnexus.taterloader.platforms.BukkitLoaderPlugin\n",
+		"paper-plugin.yml": `name: TaterLib
+version: 0.1.0
+main: dev.neuralnexus.taterloader.platforms.PaperLoaderPlugin
+api-version: '1.20'
+dependencies:
+  server:
+    LuckPerms:
+      load: BEFORE
+      required: false
+`,
+	})
+
+	result, err := mcmodmeta.ReadJarFile(jar)
+
+	assert.Nil(t, err)
+	assert.ElementsMatch(t, []mcmodmeta.Platform{mcmodmeta.PlatformBukkit, mcmodmeta.PlatformPaper}, result.Platforms())
+
+	paper := result.ModsFor(mcmodmeta.PlatformPaper)[0]
+	assert.Equal(t, "paper-plugin.yml", paper.Source)
+	assert.Equal(t, mcmodmeta.SideServer, paper.Side)
+	assert.Equal(t, []mcmodmeta.ModDependency{
+		{ID: "LuckPerms", Kind: mcmodmeta.DependencyOptional, Ordering: "AFTER"},
+	}, paper.Dependencies)
+}
+
+func TestReadJarDir(t *testing.T) {
+	dir := t.TempDir()
+	jar := writeTestJar(t, map[string]string{"velocity-plugin.json": `{"id": "taterlib"}`})
+	data, err := os.ReadFile(jar)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, content := range map[string][]byte{
+		"a-plugin.jar": data,
+		"b-broken.JAR": []byte("not a zip"),
+		"readme.txt":   []byte("ignored"),
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), content, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	jars, err := mcmodmeta.ReadJarDir(dir)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(jars))
+	assert.Equal(t, filepath.Join(dir, "a-plugin.jar"), jars[0].Path)
+	assert.Equal(t, "taterlib", jars[0].Mods[0].ID)
+	assert.Equal(t, filepath.Join(dir, "b-broken.JAR"), jars[1].Path)
+	assert.ErrorIs(t, jars[1].Errors[0], mcmodmeta.ErrNotJar)
+}
+
+func TestReadJarFileNestedJars(t *testing.T) {
+	innermost := readTestJarBytes(t, map[string]string{
+		"fabric.mod.json": `{"schemaVersion": 1, "id": "innermost", "version": "0.0.1"}`,
+	})
+	inner := readTestJarBytes(t, map[string]string{
+		"fabric.mod.json":             `{"schemaVersion": 1, "id": "inner", "version": "0.2.0", "jars": [{"file": "META-INF/jars/innermost.jar"}]}`,
+		"META-INF/jars/innermost.jar": innermost,
+	})
+	library := readTestJarBytes(t, map[string]string{
+		"META-INF/mods.toml": "modLoader = \"javafml\"\nloaderVersion = \"[47,)\"\nlicense = \"MIT\"\n[[mods]]\nmodId = \"library\"\nversion = \"1.0.5\"\n",
+	})
+
+	path := writeTestJar(t, map[string]string{
+		"fabric.mod.json":             `{"schemaVersion": 1, "id": "taterlib", "version": "1.0.0", "jars": [{"file": "META-INF/jars/inner.jar"}, {"file": "META-INF/jars/missing.jar"}]}`,
+		"META-INF/jars/inner.jar":     inner,
+		"META-INF/jars/broken.jar":    "not a zip",
+		"META-INF/jarjar/library.jar": library,
+		"META-INF/jarjar/broken.jar":  "not a zip",
+		"META-INF/jarjar/metadata.json": `{"jars": [
+  {"identifier": {"group": "dev.neuralnexus", "artifact": "library"}, "version": {"range": "[1.0,2.0)", "artifactVersion": "1.0.5"}, "path": "META-INF/jarjar/library.jar", "isObfuscated": false},
+  {"identifier": {"group": "dev.neuralnexus", "artifact": "broken"}, "version": {"range": "[1.0,)", "artifactVersion": "1.0.0"}, "path": "META-INF/jarjar/broken.jar", "isObfuscated": true}
+]}`,
+	})
+
+	jar, err := mcmodmeta.ReadJarFile(path)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(jar.Mods))
+	assert.Equal(t, 2, len(jar.JarJar.Jars))
+	assert.Equal(t, "library", jar.JarJar.Jars[0].Identifier.Artifact)
+	assert.Equal(t, "[1.0,2.0)", jar.JarJar.Jars[0].Version.Range)
+	assert.Equal(t, "1.0.5", jar.JarJar.Jars[0].Version.ArtifactVersion)
+	assert.True(t, jar.JarJar.Jars[1].IsObfuscated)
+
+	assert.Equal(t, 3, len(jar.Children))
+	assert.Equal(t, path+"!/META-INF/jars/inner.jar", jar.Children[0].Path)
+	assert.Equal(t, "inner", jar.Children[0].Mods[0].ID)
+	assert.Equal(t, 1, len(jar.Children[0].Children))
+	assert.Equal(t, path+"!/META-INF/jars/inner.jar!/META-INF/jars/innermost.jar", jar.Children[0].Children[0].Path)
+	assert.Equal(t, "innermost", jar.Children[0].Children[0].Mods[0].ID)
+
+	assert.Equal(t, "library", jar.Children[1].Mods[0].ID)
+	assert.Equal(t, mcmodmeta.PlatformForge, jar.Children[1].Mods[0].Platform)
+
+	assert.Equal(t, 0, len(jar.Children[2].Mods))
+	assert.ErrorIs(t, jar.Children[2].Errors[0], mcmodmeta.ErrNotJar)
+
+	assert.Equal(t, []mcmodmeta.Warning{{Path: "META-INF/jars/missing.jar", Message: "nested jar is declared but missing"}}, jar.Warnings)
+}
+
+func TestReadJarFileSelfNesting(t *testing.T) {
+	// Each level embeds the previous one under the same path, as a jar that contains itself would
+	descriptor := `{"schemaVersion": 1, "id": "taterlib", "version": "1.0.0", "jars": [{"file": "META-INF/jars/self.jar"}]}`
+	contents := readTestJarBytes(t, map[string]string{"fabric.mod.json": descriptor})
+	for i := 0; i < 12; i++ {
+		contents = readTestJarBytes(t, map[string]string{"fabric.mod.json": descriptor, "META-INF/jars/self.jar": contents})
+	}
+
+	jar, err := mcmodmeta.ReadJar(bytes.NewReader([]byte(contents)), int64(len(contents)), "taterlib.jar", mcmodmeta.WithMaxNestingDepth(3))
+
+	assert.Nil(t, err)
+	deepest := jar
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, 1, len(deepest.Children))
+		deepest = deepest.Children[0]
+	}
+	assert.Equal(t, "taterlib.jar!/META-INF/jars/self.jar!/META-INF/jars/self.jar!/META-INF/jars/self.jar", deepest.Path)
+	assert.Equal(t, 0, len(deepest.Children))
+	assert.Equal(t, []mcmodmeta.Warning{
+		{Path: "META-INF/jars/self.jar", Message: "nested jar is not read, it is more than 3 jars deep"},
+	}, deepest.Warnings)
+}
+
+func TestReadJarFileNestedSizeLimit(t *testing.T) {
+	padding := map[string]string{}
+	for i := 0; i < 20; i++ {
+		padding[fmt.Sprintf("padding/%d.txt", i)] = "potato"
+	}
+	path := writeTestJar(t, map[string]string{
+		"fabric.mod.json":         `{"schemaVersion": 1, "id": "taterlib", "version": "1.0.0", "jars": [{"file": "META-INF/jars/large.jar"}]}`,
+		"META-INF/jars/large.jar": readTestJarBytes(t, padding),
+	})
+
+	jar, err := mcmodmeta.ReadJarFile(path, mcmodmeta.WithMaxNestedSize(512))
+
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(jar.Children))
+	assert.Equal(t, []mcmodmeta.Warning{
+		{Path: "META-INF/jars/large.jar", Message: "nested jar is not read, it is larger than 512 bytes"},
+	}, jar.Warnings)
+
+	jar, err = mcmodmeta.ReadJarFile(path)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(jar.Children))
+}
+
+func TestReadJarFromMemory(t *testing.T) {
+	contents := readTestJarBytes(t, map[string]string{
+		"quilt.mod.json":          `{"schema_version": 1, "quilt_loader": {"id": "taterlib", "version": "1.0.0", "jars": ["META-INF/jars/inner.jar"]}}`,
+		"META-INF/jars/inner.jar": readTestJarBytes(t, map[string]string{"plugin.yml": "name: Inner\nversion: 1.0.0\nmain: dev.neuralnexus.Inner\n"}),
+	})
+
+	jar, err := mcmodmeta.ReadJar(bytes.NewReader([]byte(contents)), int64(len(contents)), "taterlib.jar")
+
+	assert.Nil(t, err)
+	assert.Equal(t, "taterlib.jar", jar.Path)
+	assert.Equal(t, "taterlib", jar.Mods[0].ID)
+	assert.Equal(t, "Inner", jar.Children[0].Mods[0].ID)
+
+	_, err = mcmodmeta.ReadJar(bytes.NewReader([]byte("nope")), 4, "nope.jar")
+	assert.ErrorIs(t, err, mcmodmeta.ErrNotJar)
+}
+
+func readTestJarBytes(t *testing.T, entries map[string]string) string {
+	t.Helper()
+
+	contents, err := os.ReadFile(writeTestJar(t, entries))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(contents)
+}
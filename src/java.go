@@ -0,0 +1,142 @@
+package mcmodmeta
+
+import (
+	"archive/zip"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type (
+	// JavaRequirement is the Java release a jar needs, both as compiled and as its mods declare
+	JavaRequirement struct {
+		ClassVersion int    // Highest Java release any class is compiled for, 0 if the jar has no classes
+		Class        string // Path of a class compiled for ClassVersion
+		Declared     []DeclaredJavaRequirement
+	}
+
+	// DeclaredJavaRequirement is a range of Java releases a mod says it runs on
+	DeclaredJavaRequirement struct {
+		Source   string // Where the requirement came from, e.g. "fabric.mod.json depends"
+		Mod      string
+		Platform Platform
+		Range    string // The range as written
+		Minimum  int    // Lowest Java release the range accepts, 0 if it could not be parsed
+	}
+)
+
+// maxJavaRelease bounds the search for the lowest Java release a declared range accepts
+const maxJavaRelease = 99
+
+// Minimum returns the lowest Java release the jar can run on: the newer of what its classes need and
+// what its mods declare. It is 0 if neither is known.
+func (r *JavaRequirement) Minimum() int {
+	minimum := r.ClassVersion
+	for _, declared := range r.Declared {
+		minimum = max(minimum, declared.Minimum)
+	}
+	return minimum
+}
+
+// Supports reports whether the jar can run on the given Java release. Declared ranges that cannot
+// be parsed are ignored.
+func (r *JavaRequirement) Supports(release int) bool {
+	if release < r.ClassVersion {
+		return false
+	}
+	for _, declared := range r.Declared {
+		if satisfied, checked := versionSatisfies(declared.Platform, declared.Range, strconv.Itoa(release)); checked && !satisfied {
+			return false
+		}
+	}
+	return true
+}
+
+// readClassVersions finds the newest class file version in the jar. Classes under META-INF/versions/
+// are only loaded by the Java release they are filed under, and module-info.class is ignored before
+// Java 9, so neither raises the minimum.
+func (s *jarScanner) readClassVersions(zipListing *zip.Reader) {
+	for _, file := range zipListing.File {
+		if !strings.HasSuffix(file.Name, ".class") || strings.HasPrefix(file.Name, "META-INF/versions/") ||
+			file.Name == "module-info.class" || strings.HasSuffix(file.Name, "/module-info.class") {
+			continue
+		}
+
+		major, err := classVersionFromFile(file)
+		if err != nil {
+			s.warn(file.Name, err.Error())
+			continue
+		}
+		if release := javaRelease(major); release > s.jar.Java.ClassVersion {
+			s.jar.Java.ClassVersion = release
+			s.jar.Java.Class = file.Name
+		}
+	}
+}
+
+// classVersionFromFile reads the major version from the header of a class file
+func classVersionFromFile(file *zip.File) (uint16, error) {
+	fileReader, err := file.Open()
+	if err != nil {
+		return 0, err
+	}
+	defer fileReader.Close()
+
+	return readClassVersion(fileReader)
+}
+
+// declaredJavaRequirements collects the Java ranges the jar's mods declare: the java dependency of
+// Fabric and Quilt mods, and the javaVersion feature of Forge and NeoForge mods
+func declaredJavaRequirements(mods []*ModMetadata) []DeclaredJavaRequirement {
+	requirements := make([]DeclaredJavaRequirement, 0)
+	for _, mod := range mods {
+		var declared, source string
+		switch raw := mod.Raw.(type) {
+		case *FabricMod, *QuiltMod:
+			for _, dep := range mod.Dependencies {
+				if dep.ID == "java" && dep.Kind == DependencyRequired {
+					declared, source = dep.VersionRange, mod.Source+" depends"
+				}
+			}
+		case *ForgeMod:
+			declared, source = javaVersionFeature(raw.Features, mod.ID), mod.Source+" features"
+		case *NeoForgeMod:
+			declared, source = javaVersionFeature(raw.Features, mod.ID), mod.Source+" features"
+		}
+		if declared == "" {
+			continue
+		}
+
+		requirements = append(requirements, DeclaredJavaRequirement{
+			Source:   source,
+			Mod:      mod.ID,
+			Platform: mod.Platform,
+			Range:    declared,
+			Minimum:  lowestJavaRelease(mod.Platform, declared),
+		})
+	}
+	return requirements
+}
+
+// javaVersionFeature returns the javaVersion feature a mods.toml declares for a mod
+func javaVersionFeature(features map[string]map[string]interface{}, modID string) string {
+	value, ok := features[modID]["javaVersion"]
+	if !ok {
+		return ""
+	}
+	return strings.TrimSpace(fmt.Sprint(value))
+}
+
+// lowestJavaRelease returns the lowest Java release a range accepts, or 0 if it cannot be parsed or accepts none
+func lowestJavaRelease(platform Platform, versionRange string) int {
+	for release := 1; release <= maxJavaRelease; release++ {
+		satisfied, checked := versionSatisfies(platform, versionRange, strconv.Itoa(release))
+		if !checked {
+			return 0
+		}
+		if satisfied {
+			return release
+		}
+	}
+	return 0
+}
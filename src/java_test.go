@@ -0,0 +1,78 @@
+package mcmodmeta_test
+
+import (
+	"encoding/binary"
+	mcmodmeta "mc-mod-metadata/src"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// classHeader returns the start of a class file compiled for the given Java release
+func classHeader(release int) string {
+	header := make([]byte, 8)
+	binary.BigEndian.PutUint32(header, 0xCAFEBABE)
+	binary.BigEndian.PutUint16(header[6:], uint16(release+44))
+	return string(header)
+}
+
+func TestJavaRequirementFromClasses(t *testing.T) {
+	jar := mustReadJar(t, writeTestJar(t, map[string]string{
+		"META-INF/MANIFEST.MF":                        "Manifest-Version: 1.0\nMulti-Release: true\n",
+		"net/example/Main.class":                      classHeader(8),
+		"net/example/Util.class":                      classHeader(17),
+		"module-info.class":                           classHeader(9),
+		"META-INF/versions/21/net/example/Util.class": classHeader(21),
+		"META-INF/versions/9/module-info.class":       classHeader(9),
+		"net/example/Broken.class":                    "not a class",
+		"assets/example/lang/en_us.json":              `{}`,
+		"fabric.mod.json":                             `{"schemaVersion": 1, "id": "example", "version": "1.0.0"}`,
+		"net/example/nested/sub/package-info.class":   classHeader(8),
+	}))
+
+	assert.Equal(t, 17, jar.Java.ClassVersion)
+	assert.Equal(t, "net/example/Util.class", jar.Java.Class)
+	assert.Equal(t, 17, jar.Java.Minimum())
+	assert.Equal(t, []mcmodmeta.Warning{{Path: "net/example/Broken.class", Message: "not a class file"}}, jar.Warnings)
+	assert.False(t, jar.Java.Supports(11))
+	assert.True(t, jar.Java.Supports(21))
+}
+
+func TestJavaRequirementDeclared(t *testing.T) {
+	jar := mustReadJar(t, writeTestJar(t, map[string]string{
+		"net/example/Main.class": classHeader(17),
+		"fabric.mod.json":        `{"schemaVersion": 1, "id": "example", "version": "1.0.0", "depends": {"java": ">=21"}}`,
+		"META-INF/neoforge.mods.toml": `
+modLoader = "javafml"
+loaderVersion = "[1,)"
+license = "MIT"
+
+[[mods]]
+modId = "example"
+version = "1.0.0"
+
+[features.example]
+javaVersion = "[21,22)"
+`,
+	}))
+
+	assert.Equal(t, 17, jar.Java.ClassVersion)
+	assert.Equal(t, []mcmodmeta.DeclaredJavaRequirement{
+		{Source: "META-INF/neoforge.mods.toml features", Mod: "example", Platform: mcmodmeta.PlatformNeoForge, Range: "[21,22)", Minimum: 21},
+		{Source: "fabric.mod.json depends", Mod: "example", Platform: mcmodmeta.PlatformFabric, Range: ">=21", Minimum: 21},
+	}, jar.Java.Declared)
+	assert.Equal(t, 21, jar.Java.Minimum())
+	assert.False(t, jar.Java.Supports(17))
+	assert.True(t, jar.Java.Supports(21))
+	assert.False(t, jar.Java.Supports(22))
+}
+
+func TestJavaRequirementEmpty(t *testing.T) {
+	jar := mustReadJar(t, writeTestJar(t, map[string]string{
+		"plugin.yml": "name: Example\nversion: 1.0\nmain: net.example.Main\n",
+	}))
+
+	assert.Equal(t, 0, jar.Java.ClassVersion)
+	assert.Equal(t, 0, jar.Java.Minimum())
+	assert.True(t, jar.Java.Supports(8))
+}
@@ -0,0 +1,135 @@
+package mcmodmeta
+
+import (
+	"bytes"
+	"fmt"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// NormalizeJSON rewrites a hand-written JSON descriptor into strict JSON.
+// It fixes the quirks commonly found in mcmod.info and fabric.mod.json files in the wild:
+// UTF-8 byte order marks, UTF-16 and Latin-1 encodings, comments, trailing commas and raw
+// control characters inside strings. Each kind of fix-up applied is described in the returned warnings.
+func NormalizeJSON(data []byte) ([]byte, []string) {
+	warnings := make([]string, 0)
+
+	data, encodingWarning := normalizeEncoding(data)
+	if encodingWarning != "" {
+		warnings = append(warnings, encodingWarning)
+	}
+
+	out := make([]byte, 0, len(data))
+	var removedComments, removedCommas, escapedControls bool
+	inString := false
+	for i := 0; i < len(data); i++ {
+		c := data[i]
+
+		if inString {
+			switch {
+			case c == '\\' && i+1 < len(data):
+				out = append(out, c, data[i+1])
+				i++
+			case c == '"':
+				out = append(out, c)
+				inString = false
+			case c == '\n':
+				out = append(out, '\\', 'n')
+				escapedControls = true
+			case c == '\r':
+				out = append(out, '\\', 'r')
+				escapedControls = true
+			case c == '\t':
+				out = append(out, '\\', 't')
+				escapedControls = true
+			case c < 0x20:
+				out = append(out, fmt.Sprintf("\\u%04x", c)...)
+				escapedControls = true
+			default:
+				out = append(out, c)
+			}
+			continue
+		}
+
+		switch {
+		case c == '"':
+			out = append(out, c)
+			inString = true
+		case c == '/' && i+1 < len(data) && data[i+1] == '/':
+			for i < len(data) && data[i] != '\n' {
+				i++
+			}
+			i--
+			removedComments = true
+		case c == '/' && i+1 < len(data) && data[i+1] == '*':
+			end := bytes.Index(data[i+2:], []byte("*/"))
+			if end < 0 {
+				i = len(data)
+			} else {
+				i += end + 3
+			}
+			removedComments = true
+		case c == '}' || c == ']':
+			trimmed := bytes.TrimRight(out, " \t\r\n")
+			if len(trimmed) > 0 && trimmed[len(trimmed)-1] == ',' {
+				out = append(trimmed[:len(trimmed)-1], out[len(trimmed):]...)
+				removedCommas = true
+			}
+			out = append(out, c)
+		default:
+			out = append(out, c)
+		}
+	}
+
+	if removedComments {
+		warnings = append(warnings, "removed comments")
+	}
+	if removedCommas {
+		warnings = append(warnings, "removed trailing commas")
+	}
+	if escapedControls {
+		warnings = append(warnings, "escaped raw control characters inside strings")
+	}
+	return out, warnings
+}
+
+// normalizeEncoding converts data to UTF-8 without a byte order mark
+func normalizeEncoding(data []byte) ([]byte, string) {
+	switch {
+	case bytes.HasPrefix(data, []byte{0xEF, 0xBB, 0xBF}):
+		return data[3:], "removed UTF-8 byte order mark"
+	case bytes.HasPrefix(data, []byte{0xFF, 0xFE}):
+		return decodeUTF16(data[2:], false), "converted from UTF-16LE"
+	case bytes.HasPrefix(data, []byte{0xFE, 0xFF}):
+		return decodeUTF16(data[2:], true), "converted from UTF-16BE"
+	case len(data) >= 2 && data[0] != 0 && data[1] == 0:
+		return decodeUTF16(data, false), "converted from UTF-16LE"
+	case len(data) >= 2 && data[0] == 0 && data[1] != 0:
+		return decodeUTF16(data, true), "converted from UTF-16BE"
+	case !utf8.Valid(data):
+		out := make([]byte, 0, len(data)*2)
+		for _, b := range data {
+			out = utf8.AppendRune(out, rune(b))
+		}
+		return out, "converted from Latin-1"
+	}
+	return data, ""
+}
+
+// decodeUTF16 decodes UTF-16 text into UTF-8
+func decodeUTF16(data []byte, bigEndian bool) []byte {
+	units := make([]uint16, 0, len(data)/2)
+	for i := 0; i+1 < len(data); i += 2 {
+		if bigEndian {
+			units = append(units, uint16(data[i])<<8|uint16(data[i+1]))
+		} else {
+			units = append(units, uint16(data[i+1])<<8|uint16(data[i]))
+		}
+	}
+
+	out := make([]byte, 0, len(units))
+	for _, r := range utf16.Decode(units) {
+		out = utf8.AppendRune(out, r)
+	}
+	return out
+}
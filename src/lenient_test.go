@@ -0,0 +1,58 @@
+package mcmodmeta_test
+
+import (
+	mcmodmeta "mc-mod-metadata/src"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeJSONQuirks(t *testing.T) {
+	quirky := "\xEF\xBB\xBF" + `[{
+  // the mod id
+  "modid": "taterlib",
+  "name": "TaterLib", /* display name */
+  "description": "line one
+line two",
+  "url": "https://some.url",
+  "authorList": ["p0t4t0sandwich",],
+}]`
+
+	normalized, warnings := mcmodmeta.NormalizeJSON([]byte(quirky))
+
+	assert.ElementsMatch(t, []string{
+		"removed UTF-8 byte order mark",
+		"removed comments",
+		"removed trailing commas",
+		"escaped raw control characters inside strings",
+	}, warnings)
+
+	mods, err := mcmodmeta.NewForgeLegacyMod(string(normalized))
+
+	assert.Nil(t, err)
+	assert.Equal(t, "taterlib", mods[0].ModID)
+	assert.Equal(t, "TaterLib", mods[0].Name)
+	assert.Equal(t, "line one\nline two", mods[0].Description)
+	assert.Equal(t, "https://some.url", mods[0].URL)
+	assert.Equal(t, []string{"p0t4t0sandwich"}, mods[0].AuthorList)
+}
+
+func TestNormalizeJSONEncodings(t *testing.T) {
+	latin1 := []byte("{\"id\": \"caf\xE9\", \"version\": \"1.0\"}")
+	normalized, warnings := mcmodmeta.NormalizeJSON(latin1)
+	assert.Equal(t, []string{"converted from Latin-1"}, warnings)
+	assert.Equal(t, `{"id": "café", "version": "1.0"}`, string(normalized))
+
+	utf16 := []byte{0xFF, 0xFE}
+	for _, c := range `{"id": "x"}` {
+		utf16 = append(utf16, byte(c), 0)
+	}
+	normalized, warnings = mcmodmeta.NormalizeJSON(utf16)
+	assert.Equal(t, []string{"converted from UTF-16LE"}, warnings)
+	assert.Equal(t, `{"id": "x"}`, string(normalized))
+
+	strict := []byte(`{"id": "x", "url": "https://a/b//c"}`)
+	normalized, warnings = mcmodmeta.NormalizeJSON(strict)
+	assert.Equal(t, 0, len(warnings))
+	assert.Equal(t, string(strict), string(normalized))
+}
@@ -0,0 +1,372 @@
+package mcmodmeta
+
+import (
+	"slices"
+	"sort"
+)
+
+type (
+	// LoadPlan is what a Bukkit or BungeeCord server will do with a set of plugins at startup
+	LoadPlan struct {
+		Order       []string            // Plugins in the order they are loaded
+		EnableOrder []string            // Plugins in the order they are enabled: STARTUP plugins first, then POSTWORLD
+		Missing     []MissingDependency // Hard dependencies that are not installed, or that failed to load themselves
+		Cycles      []DependencyCycle   // Dependency cycles, hard ones keep every plugin in them from loading
+		Failed      []string            // Plugins that will not load, because of a missing dependency or a hard cycle
+	}
+
+	// MissingDependency is a hard dependency of a plugin that cannot be satisfied
+	MissingDependency struct {
+		Plugin     string
+		Dependency string
+	}
+
+	// DependencyCycle is a chain of plugins that depend on each other, starting and ending with the same plugin
+	DependencyCycle struct {
+		Chain []string
+		Hard  bool // Every link is a hard dependency, so the server refuses to load the plugins involved
+	}
+
+	// loadNode is a plugin as the load order planner sees it
+	loadNode struct {
+		name     string
+		hard     []string
+		soft     []string
+		provides []string
+		startup  bool
+	}
+)
+
+// PlanBukkitLoadOrder computes the load and enable order a Bukkit server will use for the given plugins.
+// depend/depends and softdepend/softdepends are merged, loadbefore becomes a soft dependency of the named plugin,
+// provides satisfies dependencies on the provided names, and load: STARTUP plugins are enabled before POSTWORLD ones.
+func PlanBukkitLoadOrder(plugins []*BukkitPlugin) *LoadPlan {
+	nodes := make([]*loadNode, 0, len(plugins))
+	for _, plugin := range plugins {
+		nodes = append(nodes, &loadNode{
+			name:     plugin.Name,
+			hard:     mergeLists(plugin.Depend, plugin.Depends),
+			soft:     mergeLists(plugin.SoftDepend, plugin.SoftDepends),
+			provides: mergeLists(plugin.Provides),
+			startup:  plugin.Load == BukkitLoadStartup,
+		})
+	}
+	for i, plugin := range plugins {
+		for _, before := range mergeLists(plugin.LoadBefore) {
+			for _, node := range nodes {
+				if node.name == before || slices.Contains(node.provides, before) {
+					node.soft = mergeLists(node.soft, []string{nodes[i].name})
+				}
+			}
+		}
+	}
+	return planLoadOrder(nodes)
+}
+
+// PlanBungeeCordLoadOrder computes the load order a BungeeCord proxy will use for the given plugins.
+// BungeeCord has no loadbefore and does not break dependency cycles: every plugin in a cycle, hard or soft, fails.
+func PlanBungeeCordLoadOrder(plugins []*BungeeCordPlugin) *LoadPlan {
+	nodes := make([]*loadNode, 0, len(plugins))
+	for _, plugin := range plugins {
+		nodes = append(nodes, &loadNode{
+			name: plugin.Name,
+			hard: mergeLists(plugin.Depend, plugin.Depends),
+			soft: mergeLists(plugin.SoftDepend, plugin.SoftDepends),
+		})
+	}
+	return planBungeeCordLoadOrder(nodes)
+}
+
+// planBungeeCordLoadOrder enables each plugin after its installed dependencies, depth first, the way BungeeCord's
+// plugin manager does. A plugin fails if it is in a dependency cycle or a hard dependency is missing or failed.
+func planBungeeCordLoadOrder(nodes []*loadNode) *LoadPlan {
+	plan := newLoadPlan(len(nodes))
+	sort.SliceStable(nodes, func(i, j int) bool { return nodes[i].name < nodes[j].name })
+	plan.Cycles = findCycles(nodes)
+
+	byName := loadNodesByName(nodes)
+	enabled := map[*loadNode]bool{}
+	for _, component := range stronglyConnectedComponents(nodes, byName, true) {
+		if isCycle(component, byName, true) {
+			for _, node := range component {
+				enabled[node] = false
+			}
+		}
+	}
+
+	var enable func(node *loadNode) bool
+	enable = func(node *loadNode) bool {
+		if status, done := enabled[node]; done {
+			return status
+		}
+		status := true
+		for _, dep := range node.hard {
+			if next, ok := byName[dep]; !ok || !enable(next) {
+				plan.Missing = append(plan.Missing, MissingDependency{Plugin: node.name, Dependency: dep})
+				status = false
+			}
+		}
+		for _, dep := range node.soft {
+			if next, ok := byName[dep]; ok {
+				enable(next)
+			}
+		}
+		if status {
+			plan.Order = append(plan.Order, node.name)
+		}
+		enabled[node] = status
+		return status
+	}
+
+	for _, node := range nodes {
+		if !enable(node) {
+			plan.Failed = append(plan.Failed, node.name)
+		}
+	}
+	plan.EnableOrder = append(plan.EnableOrder, plan.Order...)
+	return plan
+}
+
+// newLoadPlan returns an empty plan for the given number of plugins
+func newLoadPlan(size int) *LoadPlan {
+	return &LoadPlan{
+		Order:       make([]string, 0, size),
+		EnableOrder: make([]string, 0, size),
+		Missing:     make([]MissingDependency, 0),
+		Cycles:      make([]DependencyCycle, 0),
+		Failed:      make([]string, 0),
+	}
+}
+
+// planLoadOrder repeatedly loads every plugin whose dependencies have loaded, the way Bukkit's plugin manager does.
+// When no plugin can make progress, one whose remaining dependencies are all soft is loaded to break the cycle;
+// if there is none, the remaining plugins are stuck in hard cycles and fail.
+func planLoadOrder(nodes []*loadNode) *LoadPlan {
+	plan := newLoadPlan(len(nodes))
+
+	sort.SliceStable(nodes, func(i, j int) bool { return nodes[i].name < nodes[j].name })
+	plan.Cycles = findCycles(nodes)
+
+	loaded := map[string]bool{}
+	remaining := slices.Clone(nodes)
+	pending := func(name string) bool {
+		return slices.ContainsFunc(remaining, func(node *loadNode) bool {
+			return node.name == name || slices.Contains(node.provides, name)
+		})
+	}
+	load := func(node *loadNode) {
+		plan.Order = append(plan.Order, node.name)
+		loaded[node.name] = true
+		for _, provided := range node.provides {
+			loaded[provided] = true
+		}
+		remaining = slices.DeleteFunc(remaining, func(other *loadNode) bool { return other == node })
+	}
+
+	for len(remaining) > 0 {
+		progressed := false
+		for _, node := range slices.Clone(remaining) {
+			missing := false
+			for _, dep := range node.hard {
+				if !loaded[dep] && !pending(dep) {
+					plan.Missing = append(plan.Missing, MissingDependency{Plugin: node.name, Dependency: dep})
+					missing = true
+				}
+			}
+			if missing {
+				plan.Failed = append(plan.Failed, node.name)
+				remaining = slices.DeleteFunc(remaining, func(other *loadNode) bool { return other == node })
+				progressed = true
+				continue
+			}
+
+			if node.ready(loaded, pending, true) {
+				load(node)
+				progressed = true
+			}
+		}
+		if progressed {
+			continue
+		}
+
+		// Nothing could load, so ignore soft dependencies for the first plugin that allows it
+		stuck := true
+		for _, node := range remaining {
+			if node.ready(loaded, pending, false) {
+				load(node)
+				stuck = false
+				break
+			}
+		}
+		if stuck {
+			for _, node := range remaining {
+				plan.Failed = append(plan.Failed, node.name)
+			}
+			remaining = nil
+		}
+	}
+
+	for _, startup := range []bool{true, false} {
+		for _, name := range plan.Order {
+			if slices.ContainsFunc(nodes, func(node *loadNode) bool { return node.name == name && node.startup == startup }) {
+				plan.EnableOrder = append(plan.EnableOrder, name)
+			}
+		}
+	}
+	return plan
+}
+
+// ready reports whether every hard dependency, and optionally every installed soft dependency, has loaded
+func (node *loadNode) ready(loaded map[string]bool, pending func(string) bool, withSoft bool) bool {
+	for _, dep := range node.hard {
+		if !loaded[dep] {
+			return false
+		}
+	}
+	if withSoft {
+		for _, dep := range node.soft {
+			if !loaded[dep] && pending(dep) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// findCycles reports one dependency cycle for each strongly connected component of the dependency graph, starting
+// from its alphabetically first plugin. The cycle is hard if the component contains a cycle of hard dependencies alone.
+func findCycles(nodes []*loadNode) []DependencyCycle {
+	byName := loadNodesByName(nodes)
+	cycles := make([]DependencyCycle, 0)
+	for _, component := range stronglyConnectedComponents(nodes, byName, true) {
+		if !isCycle(component, byName, true) {
+			continue
+		}
+		cycle := DependencyCycle{}
+		for _, hardComponent := range stronglyConnectedComponents(component, byName, false) {
+			if isCycle(hardComponent, byName, false) {
+				cycle = DependencyCycle{Chain: shortestCycle(hardComponent, byName, false), Hard: true}
+				break
+			}
+		}
+		if cycle.Chain == nil {
+			cycle.Chain = shortestCycle(component, byName, true)
+		}
+		cycles = append(cycles, cycle)
+	}
+	sort.SliceStable(cycles, func(i, j int) bool { return cycles[i].Chain[0] < cycles[j].Chain[0] })
+	return cycles
+}
+
+// loadNodesByName indexes plugins by their name and, where no plugin has that name, the names they provide
+func loadNodesByName(nodes []*loadNode) map[string]*loadNode {
+	byName := map[string]*loadNode{}
+	for _, node := range nodes {
+		byName[node.name] = node
+	}
+	for _, node := range nodes {
+		for _, provided := range node.provides {
+			if _, ok := byName[provided]; !ok {
+				byName[provided] = node
+			}
+		}
+	}
+	return byName
+}
+
+// dependencies returns the installed plugins a plugin depends on, among the given members, optionally including soft dependencies
+func (node *loadNode) dependencies(byName map[string]*loadNode, members []*loadNode, withSoft bool) []*loadNode {
+	names := node.hard
+	if withSoft {
+		names = append(slices.Clone(node.hard), node.soft...)
+	}
+	deps := make([]*loadNode, 0, len(names))
+	for _, name := range names {
+		if next, ok := byName[name]; ok && slices.Contains(members, next) && !slices.Contains(deps, next) {
+			deps = append(deps, next)
+		}
+	}
+	return deps
+}
+
+// stronglyConnectedComponents splits the plugins into groups that all depend on each other, using Tarjan's algorithm.
+// Each component is sorted by name, and components are ordered by their first plugin.
+func stronglyConnectedComponents(members []*loadNode, byName map[string]*loadNode, withSoft bool) [][]*loadNode {
+	index := map[*loadNode]int{}
+	lowLink := map[*loadNode]int{}
+	onStack := map[*loadNode]bool{}
+	stack := make([]*loadNode, 0)
+	components := make([][]*loadNode, 0)
+
+	var connect func(node *loadNode)
+	connect = func(node *loadNode) {
+		index[node] = len(index)
+		lowLink[node] = index[node]
+		stack = append(stack, node)
+		onStack[node] = true
+
+		for _, next := range node.dependencies(byName, members, withSoft) {
+			if _, visited := index[next]; !visited {
+				connect(next)
+				lowLink[node] = min(lowLink[node], lowLink[next])
+			} else if onStack[next] {
+				lowLink[node] = min(lowLink[node], index[next])
+			}
+		}
+
+		if lowLink[node] == index[node] {
+			component := make([]*loadNode, 0)
+			for {
+				top := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				onStack[top] = false
+				component = append(component, top)
+				if top == node {
+					break
+				}
+			}
+			sort.Slice(component, func(i, j int) bool { return component[i].name < component[j].name })
+			components = append(components, component)
+		}
+	}
+
+	for _, node := range members {
+		if _, visited := index[node]; !visited {
+			connect(node)
+		}
+	}
+	sort.Slice(components, func(i, j int) bool { return components[i][0].name < components[j][0].name })
+	return components
+}
+
+// isCycle reports whether a strongly connected component contains a cycle: it has several plugins, or one that depends on itself
+func isCycle(component []*loadNode, byName map[string]*loadNode, withSoft bool) bool {
+	return len(component) > 1 || slices.Contains(component[0].dependencies(byName, component, withSoft), component[0])
+}
+
+// shortestCycle finds the shortest chain of dependencies within a component from its first plugin back to itself
+func shortestCycle(component []*loadNode, byName map[string]*loadNode, withSoft bool) []string {
+	start := component[0]
+	previous := map[*loadNode]*loadNode{}
+	queue := []*loadNode{start}
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+		for _, next := range node.dependencies(byName, component, withSoft) {
+			if next == start {
+				chain := []string{start.name}
+				for step := node; step != start; step = previous[step] {
+					chain = append(chain, step.name)
+				}
+				chain = append(chain, start.name)
+				slices.Reverse(chain[1 : len(chain)-1])
+				return chain
+			}
+			if _, seen := previous[next]; !seen {
+				previous[next] = node
+				queue = append(queue, next)
+			}
+		}
+	}
+	return nil
+}
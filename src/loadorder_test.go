@@ -0,0 +1,91 @@
+package mcmodmeta_test
+
+import (
+	"fmt"
+	mcmodmeta "mc-mod-metadata/src"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlanBukkitLoadOrder(t *testing.T) {
+	plugins := []*mcmodmeta.BukkitPlugin{
+		{Name: "Essentials", Depend: []string{"Vault"}, SoftDepends: []string{"LuckPerms"}},
+		{Name: "VaultUnlocked", Provides: []string{"Vault"}},
+		{Name: "LuckPerms", Load: mcmodmeta.BukkitLoadStartup},
+		{Name: "WorldGuard", Depends: []string{"WorldEdit"}},
+		{Name: "Early", LoadBefore: []string{"Essentials"}},
+		{Name: "Orphan", Depend: []string{"NotInstalled"}},
+		{Name: "NeedsOrphan", Depend: []string{"Orphan"}},
+		{Name: "CycleA", Depend: []string{"CycleB"}},
+		{Name: "CycleB", Depend: []string{"CycleA"}},
+		{Name: "SoftA", SoftDepend: []string{"SoftB"}},
+		{Name: "SoftB", SoftDepend: []string{"SoftA"}},
+		{Name: "WorldEdit"},
+	}
+
+	plan := mcmodmeta.PlanBukkitLoadOrder(plugins)
+
+	assert.Equal(t, []string{"Early", "LuckPerms", "VaultUnlocked", "WorldEdit", "WorldGuard", "Essentials", "SoftA", "SoftB"}, plan.Order)
+	assert.Equal(t, []string{"LuckPerms", "Early", "VaultUnlocked", "WorldEdit", "WorldGuard", "Essentials", "SoftA", "SoftB"}, plan.EnableOrder)
+	assert.Equal(t, []mcmodmeta.MissingDependency{
+		{Plugin: "Orphan", Dependency: "NotInstalled"},
+		{Plugin: "NeedsOrphan", Dependency: "Orphan"},
+	}, plan.Missing)
+	assert.Equal(t, []mcmodmeta.DependencyCycle{
+		{Chain: []string{"CycleA", "CycleB", "CycleA"}, Hard: true},
+		{Chain: []string{"SoftA", "SoftB", "SoftA"}, Hard: false},
+	}, plan.Cycles)
+	assert.ElementsMatch(t, []string{"Orphan", "NeedsOrphan", "CycleA", "CycleB"}, plan.Failed)
+}
+
+func TestPlanBungeeCordLoadOrder(t *testing.T) {
+	plugins := []*mcmodmeta.BungeeCordPlugin{
+		{Name: "TaterLib", SoftDepends: []string{"LuckPerms"}},
+		{Name: "LuckPerms"},
+		{Name: "Chat", Depends: []string{"TaterLib"}, Depend: []string{"LuckPerms"}},
+	}
+
+	plan := mcmodmeta.PlanBungeeCordLoadOrder(plugins)
+
+	assert.Equal(t, []string{"LuckPerms", "TaterLib", "Chat"}, plan.Order)
+	assert.Equal(t, plan.Order, plan.EnableOrder)
+	assert.Equal(t, 0, len(plan.Missing))
+	assert.Equal(t, 0, len(plan.Cycles))
+}
+
+func TestPlanBungeeCordLoadOrderCycles(t *testing.T) {
+	plugins := []*mcmodmeta.BungeeCordPlugin{
+		{Name: "SoftA", SoftDepend: []string{"SoftB"}},
+		{Name: "SoftB", SoftDepends: []string{"SoftA"}},
+		{Name: "NeedsSoftA", Depend: []string{"SoftA"}},
+		{Name: "Early", LoadBefore: []string{"LuckPerms"}},
+		{Name: "LuckPerms", SoftDepend: []string{"SoftB"}},
+	}
+
+	plan := mcmodmeta.PlanBungeeCordLoadOrder(plugins)
+
+	assert.Equal(t, []string{"Early", "LuckPerms"}, plan.Order)
+	assert.Equal(t, []mcmodmeta.DependencyCycle{{Chain: []string{"SoftA", "SoftB", "SoftA"}, Hard: false}}, plan.Cycles)
+	assert.Equal(t, []mcmodmeta.MissingDependency{{Plugin: "NeedsSoftA", Dependency: "SoftA"}}, plan.Missing)
+	assert.Equal(t, []string{"NeedsSoftA", "SoftA", "SoftB"}, plan.Failed)
+}
+
+func TestPlanLoadOrderDenseCycle(t *testing.T) {
+	names := make([]string, 0)
+	for i := 0; i < 40; i++ {
+		names = append(names, fmt.Sprintf("Plugin%02d", i))
+	}
+	plugins := make([]*mcmodmeta.BukkitPlugin, 0)
+	for _, name := range names {
+		plugins = append(plugins, &mcmodmeta.BukkitPlugin{Name: name, SoftDepend: names})
+	}
+	plugins[1].Depend = []string{"Plugin02"}
+	plugins[2].Depend = []string{"Plugin01"}
+
+	plan := mcmodmeta.PlanBukkitLoadOrder(plugins)
+
+	assert.Equal(t, []mcmodmeta.DependencyCycle{{Chain: []string{"Plugin01", "Plugin02", "Plugin01"}, Hard: true}}, plan.Cycles)
+	assert.Equal(t, 38, len(plan.Order))
+	assert.ElementsMatch(t, []string{"Plugin01", "Plugin02"}, plan.Failed)
+}
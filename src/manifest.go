@@ -0,0 +1,173 @@
+package mcmodmeta
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Manifest is a struct that represents the META-INF/MANIFEST.MF file of a jar
+type Manifest struct {
+	MainAttributes map[string]string
+	Sections       map[string]map[string]string // Per-entry sections keyed by their Name attribute
+}
+
+// NewManifest creates a new Manifest struct from the MANIFEST.MF file, following the jar file specification:
+// sections are separated by blank lines, values longer than a line continue on lines starting with a single space,
+// and every section after the main one is a per-entry section starting with a Name attribute
+func NewManifest(manifestMF string) (*Manifest, error) {
+	manifest := &Manifest{MainAttributes: map[string]string{}, Sections: map[string]map[string]string{}}
+
+	text := strings.ReplaceAll(manifestMF, "\r\n", "\n")
+	text = strings.ReplaceAll(text, "\r", "\n")
+
+	entries := make([]map[string]string, 0)
+	section := manifest.MainAttributes
+	inMain := true
+	lastKey := ""
+	for i, line := range strings.Split(text, "\n") {
+		if line == "" {
+			inMain = false
+			section = nil
+			lastKey = ""
+			continue
+		}
+
+		if strings.HasPrefix(line, " ") {
+			if section == nil || lastKey == "" {
+				return nil, fmt.Errorf("line %d: continuation line without a header", i+1)
+			}
+			section[lastKey] += line[1:]
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, ":")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("line %d: invalid header %q", i+1, line)
+		}
+		value = strings.TrimPrefix(value, " ")
+
+		if section == nil {
+			if !inMain && !strings.EqualFold(key, "Name") {
+				return nil, fmt.Errorf("line %d: per-entry section must start with Name, found %q", i+1, key)
+			}
+			section = map[string]string{}
+			entries = append(entries, section)
+		}
+		section[key] = value
+		lastKey = key
+	}
+
+	// Sections are keyed once read, since a long Name may span continuation lines
+	for _, attributes := range entries {
+		manifest.Sections[getAttribute(attributes, "Name")] = attributes
+	}
+	return manifest, nil
+}
+
+// getAttribute looks up an attribute by name, which the specification defines as case-insensitive
+func getAttribute(attributes map[string]string, name string) string {
+	if value, ok := attributes[name]; ok {
+		return value
+	}
+	for key, value := range attributes {
+		if strings.EqualFold(key, name) {
+			return value
+		}
+	}
+	return ""
+}
+
+// Get returns a main attribute, or an empty string if it is not set
+func (m *Manifest) Get(name string) string {
+	return getAttribute(m.MainAttributes, name)
+}
+
+// GetEntry returns an attribute of the per-entry section for the named jar entry
+func (m *Manifest) GetEntry(entry string, name string) string {
+	return getAttribute(m.Sections[entry], name)
+}
+
+// getList splits a comma separated main attribute
+func (m *Manifest) getList(name string) []string {
+	return mergeLists(strings.Split(m.Get(name), ","))
+}
+
+// getBool reads a true/false main attribute
+func (m *Manifest) getBool(name string) bool {
+	return strings.EqualFold(strings.TrimSpace(m.Get(name)), "true")
+}
+
+// ImplementationVersion returns the Implementation-Version attribute, which FML uses for ${file.jarVersion}
+func (m *Manifest) ImplementationVersion() string {
+	return m.Get("Implementation-Version")
+}
+
+// ImplementationVendor returns the Implementation-Vendor attribute
+func (m *Manifest) ImplementationVendor() string {
+	return m.Get("Implementation-Vendor")
+}
+
+// AutomaticModuleName returns the JPMS module name declared by the jar
+func (m *Manifest) AutomaticModuleName() string {
+	return m.Get("Automatic-Module-Name")
+}
+
+// FMLCorePlugin returns the legacy Forge coremod class, if any
+func (m *Manifest) FMLCorePlugin() string {
+	return m.Get("FMLCorePlugin")
+}
+
+// FMLCorePluginContainsFMLMod reports whether a legacy Forge coremod jar should also be scanned for regular mods
+func (m *Manifest) FMLCorePluginContainsFMLMod() bool {
+	return m.getBool("FMLCorePluginContainsFMLMod")
+}
+
+// FMLAT returns the legacy Forge access transformer files, space separated, if any
+func (m *Manifest) FMLAT() string {
+	return m.Get("FMLAT")
+}
+
+// ForceLoadAsMod reports whether legacy Forge should scan the jar for mods even though it is also a library or coremod
+func (m *Manifest) ForceLoadAsMod() bool {
+	return m.getBool("ForceLoadAsMod")
+}
+
+// TweakClass returns the LaunchWrapper tweaker class, if any
+func (m *Manifest) TweakClass() string {
+	return m.Get("TweakClass")
+}
+
+// MixinConfigs returns the mixin configuration files registered through the manifest
+func (m *Manifest) MixinConfigs() []string {
+	return m.getList("MixinConfigs")
+}
+
+// FMLModType returns the Forge/NeoForge mod file type, e.g. MOD, LIBRARY or GAMELIBRARY
+func (m *Manifest) FMLModType() string {
+	return m.Get("FMLModType")
+}
+
+// FabricMinecraftVersion returns the Minecraft version a Fabric Loom build was compiled against
+func (m *Manifest) FabricMinecraftVersion() string {
+	return m.Get("Fabric-Minecraft-Version")
+}
+
+// FabricLoomRemap reports whether Fabric Loom should remap the jar when it is used as a dependency
+func (m *Manifest) FabricLoomRemap() bool {
+	return m.getBool("Fabric-Loom-Remap")
+}
+
+// FabricLoomMixinRemapType returns the mixin remapping mode recorded by Fabric Loom, e.g. MIXIN or STATIC
+func (m *Manifest) FabricLoomMixinRemapType() string {
+	return m.Get("Fabric-Loom-Mixin-Remap-Type")
+}
+
+// FabricMappingNamespace returns the mappings namespace the jar was built in, e.g. intermediary
+func (m *Manifest) FabricMappingNamespace() string {
+	return m.Get("Fabric-Mapping-Namespace")
+}
+
+// FabricLoomVersion returns the Fabric Loom version that built the jar
+func (m *Manifest) FabricLoomVersion() string {
+	return m.Get("Fabric-Loom-Version")
+}
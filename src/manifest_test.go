@@ -0,0 +1,59 @@
+package mcmodmeta_test
+
+import (
+	mcmodmeta "mc-mod-metadata/src"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestManifestDeserialization(t *testing.T) {
+	manifestString := "Manifest-Version: 1.0\r\n" +
+		"Implementation-Title: taterlib\r\n" +
+		"Implementation-Version: 0.1.0\r\n" +
+		"Implementation-Vendor: NeuralNexus\r\n" +
+		"Automatic-Module-Name: dev.neuralnexus.taterlib\r\n" +
+		"FMLCorePlugin: dev.neuralnexus.taterlib.forge.CorePlug\r\n" +
+		" in\r\n" +
+		"FMLCorePluginContainsFMLMod: true\r\n" +
+		"TweakClass: org.spongepowered.asm.launch.MixinTweaker\r\n" +
+		"MixinConfigs: taterlib.mixins.json,taterlib.mixins.forge.json\r\n" +
+		"FMLModType: GAMELIBRARY\r\n" +
+		"Fabric-Minecraft-Version: 1.20.1\r\n" +
+		"Fabric-Loom-Remap: true\r\n" +
+		"Fabric-Mapping-Namespace: intermediary\r\n" +
+		"\r\n" +
+		"Name: dev/neuralnexus/taterlib/\r\n" +
+		"Specification-Title: TaterLib API\r\n" +
+		"Sealed: true\r\n" +
+		"\r\n"
+
+	manifest, err := mcmodmeta.NewManifest(manifestString)
+
+	assert.Nil(t, err)
+
+	assert.Equal(t, "1.0", manifest.Get("Manifest-Version"))
+	assert.Equal(t, "taterlib", manifest.Get("implementation-title"))
+	assert.Equal(t, "0.1.0", manifest.ImplementationVersion())
+	assert.Equal(t, "NeuralNexus", manifest.ImplementationVendor())
+	assert.Equal(t, "dev.neuralnexus.taterlib", manifest.AutomaticModuleName())
+	assert.Equal(t, "dev.neuralnexus.taterlib.forge.CorePlugin", manifest.FMLCorePlugin())
+	assert.Equal(t, true, manifest.FMLCorePluginContainsFMLMod())
+	assert.Equal(t, "org.spongepowered.asm.launch.MixinTweaker", manifest.TweakClass())
+	assert.Equal(t, []string{"taterlib.mixins.json", "taterlib.mixins.forge.json"}, manifest.MixinConfigs())
+	assert.Equal(t, "GAMELIBRARY", manifest.FMLModType())
+	assert.Equal(t, "1.20.1", manifest.FabricMinecraftVersion())
+	assert.Equal(t, true, manifest.FabricLoomRemap())
+	assert.Equal(t, "intermediary", manifest.FabricMappingNamespace())
+	assert.Equal(t, 1, len(manifest.Sections))
+	assert.Equal(t, "TaterLib API", manifest.GetEntry("dev/neuralnexus/taterlib/", "Specification-Title"))
+	assert.Equal(t, "true", manifest.GetEntry("dev/neuralnexus/taterlib/", "Sealed"))
+}
+
+func TestManifestInvalid(t *testing.T) {
+	_, err := mcmodmeta.NewManifest("Manifest-Version: 1.0\nnot a header\n")
+	assert.NotNil(t, err)
+
+	_, err = mcmodmeta.NewManifest("Manifest-Version: 1.0\n\nSealed: true\n")
+	assert.NotNil(t, err)
+}
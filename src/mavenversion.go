@@ -0,0 +1,375 @@
+package mcmodmeta
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+type (
+	// MavenVersion is a version ordered the way Maven's ComparableVersion orders it,
+	// as used by Forge, NeoForge and Sponge dependency ranges
+	MavenVersion struct {
+		Raw   string
+		items *mavenList
+	}
+
+	// MavenVersionRange is a Maven version range such as "[47,)", "[1.20.1,1.21)" or "[1.0],[2.0,3.0)".
+	// A bare version without brackets is a soft requirement that any version satisfies.
+	MavenVersionRange struct {
+		Raw          string
+		Recommended  *MavenVersion // The bare version of a soft requirement, nil for bracketed ranges
+		Restrictions []MavenRestriction
+	}
+
+	// MavenRestriction is a single interval of a range, a nil bound is unbounded
+	MavenRestriction struct {
+		Lower          *MavenVersion
+		LowerInclusive bool
+		Upper          *MavenVersion
+		UpperInclusive bool
+	}
+
+	// mavenItem is a single parsed part of a version: a mavenInt, mavenString or *mavenList.
+	// A nil item stands for a missing part when two versions have different lengths.
+	mavenItem interface {
+		compare(other mavenItem) int
+		isNull() bool
+	}
+
+	mavenInt    string // Decimal digits without leading zeros, so numbers of any size compare correctly
+	mavenString string // A qualifier, with its aliases already resolved
+	mavenList   struct {
+		items []mavenItem
+	}
+)
+
+// mavenQualifiers are the well-known qualifiers in ascending order, where "" is a release
+var mavenQualifiers = []string{"alpha", "beta", "milestone", "rc", "snapshot", "", "sp"}
+
+// ParseMavenVersion parses a version into its Maven ComparableVersion items.
+// '.' separates items, while '-' and a switch between digits and letters start a nested list.
+func ParseMavenVersion(version string) *MavenVersion {
+	lower := strings.ToLower(version)
+	root := &mavenList{}
+	list := root
+	stack := []*mavenList{root}
+	push := func() {
+		child := &mavenList{}
+		list.items = append(list.items, child)
+		list = child
+		stack = append(stack, child)
+	}
+
+	isDigit := false
+	start := 0
+	for i := 0; i < len(lower); i++ {
+		c := lower[i]
+		switch {
+		case c == '.' || c == '-':
+			if i == start {
+				list.items = append(list.items, mavenInt(""))
+			} else {
+				list.items = append(list.items, parseMavenItem(isDigit, lower[start:i]))
+			}
+			start = i + 1
+			if c == '-' {
+				push()
+			}
+		case c >= '0' && c <= '9':
+			if !isDigit && i > start {
+				list.items = append(list.items, newMavenString(lower[start:i], true))
+				start = i
+				push()
+			}
+			isDigit = true
+		default:
+			if isDigit && i > start {
+				list.items = append(list.items, parseMavenItem(true, lower[start:i]))
+				start = i
+				push()
+			}
+			isDigit = false
+		}
+	}
+	if len(lower) > start {
+		list.items = append(list.items, parseMavenItem(isDigit, lower[start:]))
+	}
+
+	for i := len(stack) - 1; i >= 0; i-- {
+		stack[i].normalize()
+	}
+	return &MavenVersion{Raw: version, items: root}
+}
+
+// parseMavenItem parses the text between two separators
+func parseMavenItem(isDigit bool, text string) mavenItem {
+	if isDigit {
+		return mavenInt(strings.TrimLeft(text, "0"))
+	}
+	return newMavenString(text, false)
+}
+
+// newMavenString resolves the aliases of a qualifier. a, b and m are only short for alpha, beta and milestone
+// when directly followed by a number, as in 1.0a1.
+func newMavenString(text string, followedByDigit bool) mavenString {
+	if followedByDigit && len(text) == 1 {
+		switch text {
+		case "a":
+			return "alpha"
+		case "b":
+			return "beta"
+		case "m":
+			return "milestone"
+		}
+	}
+	switch text {
+	case "ga", "final", "release":
+		return ""
+	case "cr":
+		return "rc"
+	}
+	return mavenString(text)
+}
+
+// normalize drops trailing null items, so 1.0.0 equals 1 and 1-final equals 1
+func (l *mavenList) normalize() {
+	for i := len(l.items) - 1; i >= 0; i-- {
+		if l.items[i].isNull() {
+			l.items = slices.Delete(l.items, i, i+1)
+		} else if _, ok := l.items[i].(*mavenList); !ok {
+			break
+		}
+	}
+}
+
+func (i mavenInt) isNull() bool {
+	return i == ""
+}
+
+func (i mavenInt) compare(other mavenItem) int {
+	switch other := other.(type) {
+	case nil:
+		if i.isNull() {
+			return 0
+		}
+		return 1
+	case mavenInt:
+		if len(i) != len(other) {
+			return compareInts(len(i), len(other))
+		}
+		return strings.Compare(string(i), string(other))
+	}
+	// Numbers are newer than qualifiers and nested lists
+	return 1
+}
+
+func (s mavenString) isNull() bool {
+	return s == ""
+}
+
+// comparable orders well-known qualifiers by their index, and unknown ones lexically after all of them
+func (s mavenString) comparable() string {
+	if index := slices.Index(mavenQualifiers, string(s)); index >= 0 {
+		return strconv.Itoa(index)
+	}
+	return strconv.Itoa(len(mavenQualifiers)) + "-" + string(s)
+}
+
+func (s mavenString) compare(other mavenItem) int {
+	switch other := other.(type) {
+	case nil:
+		return strings.Compare(s.comparable(), mavenString("").comparable())
+	case mavenString:
+		return strings.Compare(s.comparable(), other.comparable())
+	case mavenInt:
+		return -1
+	}
+	// Qualifiers are older than nested lists, so 1-alpha < 1-1
+	return -1
+}
+
+func (l *mavenList) isNull() bool {
+	return len(l.items) == 0
+}
+
+func (l *mavenList) compare(other mavenItem) int {
+	switch other := other.(type) {
+	case nil:
+		if len(l.items) == 0 {
+			return 0
+		}
+		return l.items[0].compare(nil)
+	case mavenInt:
+		return -1
+	case mavenString:
+		return 1
+	case *mavenList:
+		for i := 0; i < max(len(l.items), len(other.items)); i++ {
+			var left, right mavenItem
+			if i < len(l.items) {
+				left = l.items[i]
+			}
+			if i < len(other.items) {
+				right = other.items[i]
+			}
+			var cmp int
+			if left == nil {
+				if right != nil {
+					cmp = -right.compare(nil)
+				}
+			} else {
+				cmp = left.compare(right)
+			}
+			if cmp != 0 {
+				return cmp
+			}
+		}
+	}
+	return 0
+}
+
+// compareInts returns -1, 0 or 1 depending on how a and b are ordered
+func compareInts(a int, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func (v *MavenVersion) String() string {
+	return v.Raw
+}
+
+// Compare orders two versions, returning -1, 0 or 1
+func (v *MavenVersion) Compare(other *MavenVersion) int {
+	return compareInts(v.items.compare(other.items), 0)
+}
+
+// ParseMavenVersionRange parses a Maven version range specification.
+// Bracketed restrictions are separated by commas: [ and ] include their bound, ( and ) exclude it,
+// an empty bound is unbounded and [1.0] pins an exact version.
+func ParseMavenVersionRange(spec string) (*MavenVersionRange, error) {
+	parsed := &MavenVersionRange{Raw: spec, Restrictions: make([]MavenRestriction, 0)}
+	rest := strings.TrimSpace(spec)
+	if rest == "" {
+		return nil, fmt.Errorf("empty version range")
+	}
+
+	if !strings.HasPrefix(rest, "[") && !strings.HasPrefix(rest, "(") {
+		if strings.ContainsAny(rest, "[](),") {
+			return nil, fmt.Errorf("invalid version range %q", spec)
+		}
+		parsed.Recommended = ParseMavenVersion(rest)
+		parsed.Restrictions = append(parsed.Restrictions, MavenRestriction{})
+		return parsed, nil
+	}
+
+	for rest != "" {
+		end := strings.IndexAny(rest, "])")
+		if end < 0 {
+			return nil, fmt.Errorf("unbounded version range %q", spec)
+		}
+		restriction, err := parseMavenRestriction(rest[:end+1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid version range %q: %w", spec, err)
+		}
+		// Like Maven, restrictions only overlap when both bounds are set, and a shared bound only when both include it
+		if count := len(parsed.Restrictions); count > 0 && parsed.Restrictions[count-1].Upper != nil && restriction.Lower != nil {
+			previous := parsed.Restrictions[count-1]
+			if cmp := restriction.Lower.Compare(previous.Upper); cmp < 0 || (cmp == 0 && restriction.LowerInclusive && previous.UpperInclusive) {
+				return nil, fmt.Errorf("overlapping restrictions in version range %q", spec)
+			}
+		}
+		parsed.Restrictions = append(parsed.Restrictions, restriction)
+
+		rest = strings.TrimSpace(rest[end+1:])
+		if rest != "" {
+			if rest[0] != ',' {
+				return nil, fmt.Errorf("invalid version range %q", spec)
+			}
+			rest = strings.TrimSpace(rest[1:])
+			if rest == "" || (rest[0] != '[' && rest[0] != '(') {
+				return nil, fmt.Errorf("invalid version range %q", spec)
+			}
+		}
+	}
+	return parsed, nil
+}
+
+// parseMavenRestriction parses a single bracketed restriction such as [1.20.1,1.21) or [1.0]
+func parseMavenRestriction(spec string) (MavenRestriction, error) {
+	restriction := MavenRestriction{
+		LowerInclusive: spec[0] == '[',
+		UpperInclusive: spec[len(spec)-1] == ']',
+	}
+	inner := strings.TrimSpace(spec[1 : len(spec)-1])
+
+	lower, upper, isRange := strings.Cut(inner, ",")
+	if !isRange {
+		if !restriction.LowerInclusive || !restriction.UpperInclusive || inner == "" {
+			return restriction, fmt.Errorf("single version %s must be written as [version]", spec)
+		}
+		restriction.Lower = ParseMavenVersion(inner)
+		restriction.Upper = restriction.Lower
+		return restriction, nil
+	}
+	if strings.Contains(upper, ",") {
+		return restriction, fmt.Errorf("too many bounds in %s", spec)
+	}
+
+	if lower = strings.TrimSpace(lower); lower != "" {
+		restriction.Lower = ParseMavenVersion(lower)
+	}
+	if upper = strings.TrimSpace(upper); upper != "" {
+		restriction.Upper = ParseMavenVersion(upper)
+	}
+	if restriction.Lower != nil && restriction.Upper != nil {
+		cmp := restriction.Lower.Compare(restriction.Upper)
+		if cmp > 0 || (cmp == 0 && !(restriction.LowerInclusive && restriction.UpperInclusive)) {
+			return restriction, fmt.Errorf("lower bound is above upper bound in %s", spec)
+		}
+	}
+	return restriction, nil
+}
+
+// Contains reports whether the version is within the restriction
+func (r MavenRestriction) Contains(version *MavenVersion) bool {
+	if r.Lower != nil {
+		cmp := version.Compare(r.Lower)
+		if cmp < 0 || (cmp == 0 && !r.LowerInclusive) {
+			return false
+		}
+	}
+	if r.Upper != nil {
+		cmp := version.Compare(r.Upper)
+		if cmp > 0 || (cmp == 0 && !r.UpperInclusive) {
+			return false
+		}
+	}
+	return true
+}
+
+// Contains reports whether the version satisfies any restriction of the range
+func (r *MavenVersionRange) Contains(version string) bool {
+	parsed := ParseMavenVersion(version)
+	for _, restriction := range r.Restrictions {
+		if restriction.Contains(parsed) {
+			return true
+		}
+	}
+	return false
+}
+
+// MavenRangeSatisfied reports whether a version satisfies a Maven version range specification
+func MavenRangeSatisfied(spec string, version string) (bool, error) {
+	versionRange, err := ParseMavenVersionRange(spec)
+	if err != nil {
+		return false, err
+	}
+	return versionRange.Contains(version), nil
+}
@@ -0,0 +1,63 @@
+package mcmodmeta_test
+
+import (
+	mcmodmeta "mc-mod-metadata/src"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMavenVersionCompare(t *testing.T) {
+	ordered := []string{"1-alpha", "1-alpha2", "1-beta", "1-milestone1", "1-rc1", "1-snapshot", "1", "1-sp", "1-abc", "1-1", "1.0.1", "1.1", "1.20.1", "1.21", "47.1.0", "47.2.0", "47.10.0", "100000000000000000000"}
+	for i := 1; i < len(ordered); i++ {
+		a, b := mcmodmeta.ParseMavenVersion(ordered[i-1]), mcmodmeta.ParseMavenVersion(ordered[i])
+		assert.Equal(t, -1, a.Compare(b), "%s < %s", a, b)
+		assert.Equal(t, 1, b.Compare(a), "%s > %s", b, a)
+	}
+
+	for _, equal := range [][2]string{{"1", "1.0.0"}, {"1-final", "1"}, {"1.0-GA", "1"}, {"1cr1", "1-rc-1"}, {"1.0a1", "1-alpha-1"}, {"01", "1"}} {
+		assert.Equal(t, 0, mcmodmeta.ParseMavenVersion(equal[0]).Compare(mcmodmeta.ParseMavenVersion(equal[1])), "%s == %s", equal[0], equal[1])
+	}
+}
+
+func TestMavenVersionRanges(t *testing.T) {
+	cases := []struct {
+		spec     string
+		version  string
+		expected bool
+	}{
+		{"[47,)", "47.2.0", true},
+		{"[47,)", "46.0.1", false},
+		{"[1.20.1,1.21)", "1.20.1", true},
+		{"[1.20.1,1.21)", "1.20.6", true},
+		{"[1.20.1,1.21)", "1.21", false},
+		{"(1.20.1,1.21]", "1.20.1", false},
+		{"(1.20.1,1.21]", "1.21", true},
+		{"(,1.19]", "1.18.2", true},
+		{"[1.0]", "1.0.0", true},
+		{"[1.0]", "1.0.1", false},
+		{"[1.16.5],[1.18,1.19)", "1.18.2", true},
+		{"[1.16.5],[1.18,1.19)", "1.17.1", false},
+		{"1.0", "3.0", true},
+		{"[1.0,),[2.0,3.0)", "2.5", true},
+		{"[1.0,),[2.0,3.0)", "0.9", false},
+		{"[1.0,2.0),[2.0,3.0)", "2.0", true},
+		{"(,1.0],(,2.0]", "1.5", true},
+	}
+	for _, c := range cases {
+		satisfied, err := mcmodmeta.MavenRangeSatisfied(c.spec, c.version)
+		assert.Nil(t, err, c.spec)
+		assert.Equal(t, c.expected, satisfied, "%q against %q", c.version, c.spec)
+	}
+
+	versionRange, err := mcmodmeta.ParseMavenVersionRange("[1.20.1,1.21),[1.21.1,)")
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(versionRange.Restrictions))
+	assert.Nil(t, versionRange.Restrictions[1].Upper)
+	assert.Equal(t, "1.21.1", versionRange.Restrictions[1].Lower.String())
+
+	for _, invalid := range []string{"", "[1.0", "(1.0)", "[2.0,1.0]", "[1.0,2.0],[1.5,)", "[1.0,2.0],[2.0,3.0]", "[1.0]1.1", "[1,2,3]"} {
+		_, err := mcmodmeta.ParseMavenVersionRange(invalid)
+		assert.NotNil(t, err, invalid)
+	}
+}
@@ -0,0 +1,624 @@
+package mcmodmeta
+
+import (
+	"slices"
+	"sort"
+	"strings"
+)
+
+// Platform is the mod loader or server platform a descriptor targets
+type Platform string
+
+const (
+	PlatformBukkit     Platform = "bukkit"
+	PlatformBungeeCord Platform = "bungeecord"
+	PlatformFabric     Platform = "fabric"
+	PlatformForge      Platform = "forge"
+	PlatformNeoForge   Platform = "neoforge"
+	PlatformPaper      Platform = "paper"
+	PlatformQuilt      Platform = "quilt"
+	PlatformSponge     Platform = "sponge"
+	PlatformVelocity   Platform = "velocity"
+)
+
+// Side is the physical side (client or dedicated server) a mod runs on
+type Side string
+
+const (
+	SideUnknown Side = "unknown"
+	SideClient  Side = "client"
+	SideServer  Side = "server"
+	SideBoth    Side = "both"
+)
+
+// DependencyKind describes how a mod relates to one of its dependencies
+type DependencyKind string
+
+const (
+	DependencyRequired     DependencyKind = "required"
+	DependencyOptional     DependencyKind = "optional"
+	DependencyRecommended  DependencyKind = "recommended"
+	DependencyDiscouraged  DependencyKind = "discouraged"
+	DependencyIncompatible DependencyKind = "incompatible"
+)
+
+type (
+	// ModMetadata is a loader-agnostic view of a single mod or plugin
+	ModMetadata struct {
+		ID           string
+		Name         string
+		Version      string
+		Authors      []string
+		Description  string
+		License      string
+		Links        ModLinks
+		Dependencies []ModDependency
+		Side         Side
+		Platform     Platform
+		Source       string // Path of the descriptor inside the jar, e.g. META-INF/mods.toml
+		Raw          any    // The typed descriptor struct, e.g. *FabricMod or *ForgeMod
+	}
+
+	// JarMetadata is everything read from a single jar, which may target several platforms at once
+	JarMetadata struct {
+		Path           string
+		Mods           []*ModMetadata
+		ModAnnotations []*ForgeModAnnotation // @Mod annotations of legacy Forge mods whose mcmod.info is missing or incomplete
+		Manifest       *Manifest             // nil if the jar has no readable META-INF/MANIFEST.MF
+		JarJar         *JarJarMetadata       // nil if the jar has no readable META-INF/jarjar/metadata.json
+		Children       []*JarMetadata        // Jars embedded in this one, with paths of the form outer.jar!/META-INF/jars/inner.jar
+		Java           JavaRequirement       // The Java release the jar's own classes and mods need
+		Errors         []error               // Per-descriptor failures, each a *MalformedDescriptorError or *MissingFieldError
+		Warnings       []Warning
+	}
+
+	// Warning is a non-fatal problem found while reading a jar
+	Warning struct {
+		Path    string // Path of the offending file inside the jar
+		Message string
+	}
+
+	// ModLinks holds the URLs a mod advertises
+	ModLinks struct {
+		Homepage  string
+		Source    string
+		Issues    string
+		UpdateURL string
+	}
+
+	// ModDependency is a loader-agnostic dependency declaration
+	ModDependency struct {
+		ID           string
+		VersionRange string // In the declaring loader's own syntax, empty if any version is accepted
+		Kind         DependencyKind
+		Ordering     string // BEFORE, AFTER or NONE where the loader supports it
+		Side         string // CLIENT, SERVER or BOTH where the loader supports it
+	}
+)
+
+func (w Warning) String() string {
+	return w.Path + ": " + w.Message
+}
+
+// Platforms returns every platform the jar ships a descriptor for, in the order they were found
+func (jar *JarMetadata) Platforms() []Platform {
+	platforms := make([]Platform, 0)
+	for _, mod := range jar.Mods {
+		if !slices.Contains(platforms, mod.Platform) {
+			platforms = append(platforms, mod.Platform)
+		}
+	}
+	return platforms
+}
+
+// ModsFor returns the mods the jar declares for the given platform
+func (jar *JarMetadata) ModsFor(platform Platform) []*ModMetadata {
+	mods := make([]*ModMetadata, 0)
+	for _, mod := range jar.Mods {
+		if mod.Platform == platform {
+			mods = append(mods, mod)
+		}
+	}
+	return mods
+}
+
+// Supports reports whether the jar ships a descriptor for the given platform
+func (jar *JarMetadata) Supports(platform Platform) bool {
+	return slices.Contains(jar.Platforms(), platform)
+}
+
+// newBukkitMetadata converts a BukkitPlugin into a ModMetadata
+func newBukkitMetadata(plugin *BukkitPlugin, source string) *ModMetadata {
+	deps := make([]ModDependency, 0)
+	for _, id := range mergeLists(plugin.Depend, plugin.Depends) {
+		deps = append(deps, ModDependency{ID: id, Kind: DependencyRequired})
+	}
+	for _, id := range mergeLists(plugin.SoftDepend, plugin.SoftDepends) {
+		deps = append(deps, ModDependency{ID: id, Kind: DependencyOptional})
+	}
+
+	return &ModMetadata{
+		ID:           plugin.Name,
+		Name:         plugin.Name,
+		Version:      plugin.Version,
+		Authors:      mergeLists([]string{plugin.Author}, plugin.Authors),
+		Description:  plugin.Description,
+		Links:        ModLinks{Homepage: plugin.Website},
+		Dependencies: deps,
+		Side:         SideServer,
+		Platform:     PlatformBukkit,
+		Source:       source,
+		Raw:          plugin,
+	}
+}
+
+// newPaperMetadata converts a PaperPlugin into a ModMetadata
+func newPaperMetadata(plugin *PaperPlugin, source string) *ModMetadata {
+	deps := make([]ModDependency, 0)
+	for _, group := range []map[string]PaperDependency{plugin.Dependencies.Server, plugin.Dependencies.Bootstrap} {
+		groupDeps := make([]ModDependency, 0, len(group))
+		for id, dep := range group {
+			if slices.ContainsFunc(deps, func(existing ModDependency) bool { return existing.ID == id }) {
+				continue
+			}
+
+			kind := DependencyOptional
+			if dep.Required {
+				kind = DependencyRequired
+			}
+			// Paper's load describes the dependency relative to the plugin, ModDependency.Ordering the reverse
+			ordering := "NONE"
+			switch strings.ToUpper(dep.Load) {
+			case "BEFORE":
+				ordering = "AFTER"
+			case "AFTER":
+				ordering = "BEFORE"
+			}
+			groupDeps = append(groupDeps, ModDependency{ID: id, Kind: kind, Ordering: ordering})
+		}
+		sortDependencies(groupDeps)
+		deps = append(deps, groupDeps...)
+	}
+
+	return &ModMetadata{
+		ID:           plugin.Name,
+		Name:         plugin.Name,
+		Version:      plugin.Version,
+		Authors:      mergeLists([]string{plugin.Author}, plugin.Authors),
+		Description:  plugin.Description,
+		Links:        ModLinks{Homepage: plugin.Website},
+		Dependencies: deps,
+		Side:         SideServer,
+		Platform:     PlatformPaper,
+		Source:       source,
+		Raw:          plugin,
+	}
+}
+
+// newBungeeCordMetadata converts a BungeeCordPlugin into a ModMetadata
+func newBungeeCordMetadata(plugin *BungeeCordPlugin, source string) *ModMetadata {
+	deps := make([]ModDependency, 0)
+	for _, id := range mergeLists(plugin.Depend, plugin.Depends) {
+		deps = append(deps, ModDependency{ID: id, Kind: DependencyRequired})
+	}
+	for _, id := range mergeLists(plugin.SoftDepend, plugin.SoftDepends) {
+		deps = append(deps, ModDependency{ID: id, Kind: DependencyOptional})
+	}
+
+	return &ModMetadata{
+		ID:           plugin.Name,
+		Name:         plugin.Name,
+		Version:      plugin.Version,
+		Authors:      mergeLists([]string{plugin.Author}, plugin.Authors),
+		Description:  plugin.Description,
+		Links:        ModLinks{Homepage: plugin.Website},
+		Dependencies: deps,
+		Side:         SideServer,
+		Platform:     PlatformBungeeCord,
+		Source:       source,
+		Raw:          plugin,
+	}
+}
+
+// newFabricMetadata converts a FabricMod into a ModMetadata
+func newFabricMetadata(mod *FabricMod, source string) *ModMetadata {
+	authors := make([]string, 0)
+	for _, author := range mod.Authors {
+		if person, ok := author.(FabricPerson); ok && person.Name != "" {
+			authors = append(authors, person.Name)
+		}
+	}
+
+	deps := make([]ModDependency, 0)
+	deps = append(deps, fabricDependencies(mod.Depends, DependencyRequired)...)
+	deps = append(deps, fabricDependencies(mod.Recommends, DependencyRecommended)...)
+	deps = append(deps, fabricDependencies(mod.Suggests, DependencyOptional)...)
+	deps = append(deps, fabricDependencies(mod.Conflicts, DependencyDiscouraged)...)
+	deps = append(deps, fabricDependencies(mod.Breaks, DependencyIncompatible)...)
+
+	return &ModMetadata{
+		ID:          mod.ID,
+		Name:        mod.Name,
+		Version:     mod.Version,
+		Authors:     authors,
+		Description: mod.Description,
+		License:     strings.Join(mod.License, " OR "),
+		Links: ModLinks{
+			Homepage: mod.Contact.HomePage,
+			Source:   mod.Contact.Sources,
+			Issues:   mod.Contact.Issues,
+		},
+		Dependencies: deps,
+		Side:         fabricSide(mod.Environment),
+		Platform:     PlatformFabric,
+		Source:       source,
+		Raw:          mod,
+	}
+}
+
+// fabricDependencies converts a Fabric dependency map, where each value is a version predicate or a list of them
+func fabricDependencies(depends map[string]any, kind DependencyKind) []ModDependency {
+	deps := make([]ModDependency, 0, len(depends))
+	for id, predicate := range depends {
+		deps = append(deps, ModDependency{ID: id, VersionRange: fabricPredicateString(predicate), Kind: kind})
+	}
+	sortDependencies(deps)
+	return deps
+}
+
+// fabricPredicateString flattens a Fabric version predicate, joining list alternatives with " || "
+func fabricPredicateString(predicate any) string {
+	switch value := predicate.(type) {
+	case string:
+		return value
+	case []any:
+		parts := make([]string, 0, len(value))
+		for _, part := range value {
+			if str, ok := part.(string); ok {
+				parts = append(parts, str)
+			}
+		}
+		return strings.Join(parts, " || ")
+	}
+	return ""
+}
+
+// newQuiltMetadata converts a QuiltMod into a ModMetadata
+func newQuiltMetadata(mod *QuiltMod, source string) *ModMetadata {
+	loader := mod.QuiltLoader
+
+	authors := make([]string, 0, len(loader.Metadata.Contributors))
+	for name := range loader.Metadata.Contributors {
+		authors = append(authors, name)
+	}
+	sort.Strings(authors)
+
+	licenses := make([]string, 0, len(loader.Metadata.License))
+	for _, license := range loader.Metadata.License {
+		licenses = append(licenses, firstNonEmpty(license.ID, license.Name))
+	}
+
+	deps := make([]ModDependency, 0)
+	deps = append(deps, quiltDependencies(loader.Depends, DependencyRequired)...)
+	deps = append(deps, quiltDependencies(loader.Breaks, DependencyIncompatible)...)
+
+	return &ModMetadata{
+		ID:          loader.ID,
+		Name:        loader.Metadata.Name,
+		Version:     loader.Version,
+		Authors:     authors,
+		Description: loader.Metadata.Description,
+		License:     strings.Join(licenses, " OR "),
+		Links: ModLinks{
+			Homepage: loader.Metadata.Contact["homepage"],
+			Source:   loader.Metadata.Contact["sources"],
+			Issues:   loader.Metadata.Contact["issues"],
+		},
+		Dependencies: deps,
+		Side:         quiltSide(mod.Minecraft.Environment),
+		Platform:     PlatformQuilt,
+		Source:       source,
+		Raw:          mod,
+	}
+}
+
+// quiltDependencies converts Quilt depends/breaks entries.
+// Members of an any-of group are each reported as optional, since no single one of them is required.
+func quiltDependencies(depends []QuiltDependency, kind DependencyKind) []ModDependency {
+	deps := make([]ModDependency, 0, len(depends))
+	for _, dep := range depends {
+		if len(dep.AnyOf) > 0 {
+			for _, member := range quiltDependencies(dep.AnyOf, kind) {
+				if kind == DependencyRequired {
+					member.Kind = DependencyOptional
+				}
+				deps = append(deps, member)
+			}
+			continue
+		}
+
+		depKind := kind
+		if dep.Optional && kind == DependencyRequired {
+			depKind = DependencyOptional
+		}
+		deps = append(deps, ModDependency{ID: dep.ID, VersionRange: strings.Join(dep.Versions, " || "), Kind: depKind})
+	}
+	return deps
+}
+
+// newForgeLegacyMetadata converts a ForgeLegacyMod into a ModMetadata
+func newForgeLegacyMetadata(mod *ForgeLegacyMod, source string) *ModMetadata {
+	deps := make([]ModDependency, 0)
+	for _, id := range mod.RequiredMods {
+		deps = append(deps, ModDependency{ID: id, Kind: DependencyRequired})
+	}
+	for _, id := range mod.Dependencies {
+		if !slices.Contains(mod.RequiredMods, id) {
+			deps = append(deps, ModDependency{ID: id, Kind: DependencyOptional, Ordering: "AFTER"})
+		}
+	}
+	if mod.MCVersion != "" {
+		deps = append(deps, ModDependency{ID: "minecraft", VersionRange: mod.MCVersion, Kind: DependencyRequired})
+	}
+
+	return &ModMetadata{
+		ID:           mod.ModID,
+		Name:         mod.Name,
+		Version:      mod.Version,
+		Authors:      mergeLists(mod.AuthorList),
+		Description:  mod.Description,
+		License:      mod.License,
+		Links:        ModLinks{Homepage: mod.URL, UpdateURL: firstNonEmpty(mod.UpdateJSON, mod.UpdateURL)},
+		Dependencies: deps,
+		Side:         SideUnknown,
+		Platform:     PlatformForge,
+		Source:       source,
+		Raw:          mod,
+	}
+}
+
+// newForgeAnnotationMetadata converts a ForgeModAnnotation into a ModMetadata
+func newForgeAnnotationMetadata(annotation *ForgeModAnnotation, source string) *ModMetadata {
+	mod := &ModMetadata{
+		ID:           annotation.ModID,
+		Name:         annotation.Name,
+		Version:      annotation.Version,
+		Authors:      make([]string, 0),
+		Dependencies: make([]ModDependency, 0),
+		Side:         SideUnknown,
+		Platform:     PlatformForge,
+		Source:       source,
+		Raw:          annotation,
+	}
+	fillFromModAnnotation(mod, annotation, nil)
+	return mod
+}
+
+// fillFromModAnnotation fills in whatever mcmod.info left empty, or with placeholders that cannot be resolved,
+// from the mod's @Mod annotation. The mod's Raw descriptor is left as written.
+func fillFromModAnnotation(mod *ModMetadata, annotation *ForgeModAnnotation, placeholders map[string]string) {
+	if mod.Name == "" {
+		mod.Name = annotation.Name
+	}
+	if _, unresolved := substitutePlaceholders(mod.Version, placeholders); mod.Version == "" || len(unresolved) > 0 {
+		mod.Version = firstNonEmpty(annotation.Version, mod.Version)
+	}
+
+	deps := annotation.ParsedDependencies()
+	if annotation.AcceptedMinecraftVersions != "" {
+		deps = append(deps, ModDependency{ID: "minecraft", VersionRange: annotation.AcceptedMinecraftVersions, Kind: DependencyRequired})
+	}
+	for _, dep := range deps {
+		if !slices.ContainsFunc(mod.Dependencies, func(existing ModDependency) bool { return existing.ID == dep.ID }) {
+			mod.Dependencies = append(mod.Dependencies, dep)
+		}
+	}
+
+	if mod.Side == SideUnknown {
+		switch {
+		case annotation.ClientSideOnly:
+			mod.Side = SideClient
+		case annotation.ServerSideOnly:
+			mod.Side = SideServer
+		}
+	}
+}
+
+// newForgeMetadata converts a single [[mods]] entry of a ForgeMod into a ModMetadata
+func newForgeMetadata(mod *ForgeMod, info ForgeModInfo, source string) *ModMetadata {
+	deps := make([]ModDependency, 0)
+	for _, dep := range modDependencies(mod.Dependencies, info.ModID) {
+		kind := DependencyOptional
+		if dep.Mandatory {
+			kind = DependencyRequired
+		}
+		deps = append(deps, ModDependency{
+			ID:           dep.ModID,
+			VersionRange: dep.VersionRange,
+			Kind:         kind,
+			Ordering:     dep.Ordering,
+			Side:         dep.Side,
+		})
+	}
+
+	return &ModMetadata{
+		ID:           info.ModID,
+		Name:         info.DisplayName,
+		Version:      info.Version,
+		Authors:      splitAuthors(info.Authors),
+		Description:  strings.TrimSpace(info.Description),
+		License:      mod.License,
+		Links:        ModLinks{Homepage: info.DisplayURL, Issues: mod.IssueTrackerURL, UpdateURL: info.UpdateJSONURL},
+		Dependencies: deps,
+		Side:         forgeSide(mod.ClientSideOnly, info.DisplayTest, deps),
+		Platform:     PlatformForge,
+		Source:       source,
+		Raw:          mod,
+	}
+}
+
+// newNeoForgeMetadata converts a single [[mods]] entry of a NeoForgeMod into a ModMetadata
+func newNeoForgeMetadata(mod *NeoForgeMod, info NeoForgeModInfo, source string) *ModMetadata {
+	deps := make([]ModDependency, 0)
+	for _, dep := range modDependencies(mod.Dependencies, info.ModID) {
+		kind := DependencyKind(strings.ToLower(dep.Type))
+		if kind == "" {
+			kind = DependencyRequired
+		}
+		deps = append(deps, ModDependency{
+			ID:           dep.ModID,
+			VersionRange: dep.VersionRange,
+			Kind:         kind,
+			Ordering:     dep.Ordering,
+			Side:         dep.Side,
+		})
+	}
+
+	return &ModMetadata{
+		ID:           info.ModID,
+		Name:         info.DisplayName,
+		Version:      info.Version,
+		Authors:      splitAuthors(info.Authors),
+		Description:  strings.TrimSpace(info.Description),
+		License:      mod.License,
+		Links:        ModLinks{Homepage: info.DisplayURL, Issues: mod.IssueTrackerURL, UpdateURL: info.UpdateJSONURL},
+		Dependencies: deps,
+		Side:         forgeSide(false, info.DisplayTest, deps),
+		Platform:     PlatformNeoForge,
+		Source:       source,
+		Raw:          mod,
+	}
+}
+
+// newSpongeMetadata converts a single plugin entry of a SpongePlugin into a ModMetadata,
+// falling back to the global properties where the plugin does not set its own
+func newSpongeMetadata(plugin *SpongePlugin, info SpongePluginInfo, source string) *ModMetadata {
+	contributors := info.Contributors
+	if len(contributors) == 0 {
+		contributors = plugin.Global.Contributors
+	}
+	authors := make([]string, 0, len(contributors))
+	for _, contributor := range contributors {
+		authors = append(authors, contributor.Name)
+	}
+
+	links := info.Links
+	if links == (SpongeLinks{}) {
+		links = plugin.Global.Links
+	}
+
+	deps := make([]ModDependency, 0)
+	for _, dep := range append(append([]SpongeDependency{}, plugin.Global.Dependencies...), info.Dependencies...) {
+		kind := DependencyRequired
+		if dep.Optional {
+			kind = DependencyOptional
+		}
+		deps = append(deps, ModDependency{
+			ID:           dep.ID,
+			VersionRange: dep.Version,
+			Kind:         kind,
+			Ordering:     strings.ToUpper(dep.LoadOrder),
+		})
+	}
+
+	return &ModMetadata{
+		ID:           info.ID,
+		Name:         info.Name,
+		Version:      firstNonEmpty(info.Version, plugin.Global.Version),
+		Authors:      authors,
+		Description:  info.Description,
+		License:      plugin.License,
+		Links:        ModLinks{Homepage: links.Homepage, Source: links.Source, Issues: links.Issues},
+		Dependencies: deps,
+		Side:         SideServer,
+		Platform:     PlatformSponge,
+		Source:       source,
+		Raw:          plugin,
+	}
+}
+
+// newVelocityMetadata converts a VelocityPlugin into a ModMetadata
+func newVelocityMetadata(plugin *VelocityPlugin, source string) *ModMetadata {
+	deps := make([]ModDependency, 0, len(plugin.Dependencies))
+	for _, dep := range plugin.Dependencies {
+		kind := DependencyRequired
+		if dep.Optional {
+			kind = DependencyOptional
+		}
+		deps = append(deps, ModDependency{ID: dep.ID, Kind: kind})
+	}
+
+	return &ModMetadata{
+		ID:           plugin.ID,
+		Name:         plugin.Name,
+		Version:      plugin.Version,
+		Authors:      mergeLists(plugin.Authors),
+		Description:  plugin.Description,
+		Links:        ModLinks{Homepage: plugin.URL},
+		Dependencies: deps,
+		Side:         SideServer,
+		Platform:     PlatformVelocity,
+		Source:       source,
+		Raw:          plugin,
+	}
+}
+
+// mergeLists concatenates string lists, dropping empty and duplicate entries
+func mergeLists(lists ...[]string) []string {
+	merged := make([]string, 0)
+	for _, list := range lists {
+		for _, item := range list {
+			item = strings.TrimSpace(item)
+			if item != "" && !slices.Contains(merged, item) {
+				merged = append(merged, item)
+			}
+		}
+	}
+	return merged
+}
+
+// splitAuthors splits the free-form authors string used by mods.toml
+func splitAuthors(authors string) []string {
+	return mergeLists(strings.Split(authors, ","))
+}
+
+// modDependencies returns the [[dependencies.<modId>]] list declared for modID.
+// Falls back to a case-insensitive match, since the table key is hand-written and often differs in case.
+func modDependencies[T any](dependencies map[string][]T, modID string) []T {
+	if deps, ok := dependencies[modID]; ok {
+		return deps
+	}
+	for key, deps := range dependencies {
+		if strings.EqualFold(key, modID) {
+			return deps
+		}
+	}
+	return nil
+}
+
+// sortedKeys returns the keys of a string-keyed map in order
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// firstNonEmpty returns the first non-empty string
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// sortDependencies orders dependencies by ID so map-backed declarations are stable
+func sortDependencies(deps []ModDependency) {
+	sort.Slice(deps, func(i, j int) bool {
+		return deps[i].ID < deps[j].ID
+	})
+}
@@ -0,0 +1,265 @@
+package mcmodmeta
+
+import (
+	_ "embed"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// MinecraftVersionType is the kind of Minecraft version
+type MinecraftVersionType string
+
+const (
+	MinecraftRelease          MinecraftVersionType = "release"
+	MinecraftSnapshot         MinecraftVersionType = "snapshot"
+	MinecraftPreRelease       MinecraftVersionType = "pre_release"
+	MinecraftReleaseCandidate MinecraftVersionType = "release_candidate"
+	MinecraftOldBeta          MinecraftVersionType = "old_beta"
+	MinecraftOldAlpha         MinecraftVersionType = "old_alpha"
+	MinecraftInfdev           MinecraftVersionType = "infdev"
+	MinecraftIndev            MinecraftVersionType = "indev"
+	MinecraftClassic          MinecraftVersionType = "classic"
+	MinecraftPreClassic       MinecraftVersionType = "pre_classic"
+)
+
+// MinecraftVersion is a parsed Minecraft: Java Edition version, ordered by when it was released
+type MinecraftVersion struct {
+	Raw     string
+	Type    MinecraftVersionType
+	Release string // The release this version is, or leads up to; empty for old versions and unknown weekly snapshots
+	Number  int    // The number of a pre-release, release candidate or new-style snapshot
+
+	era     int   // Position of Type among the historical development phases
+	target  []int // Components of Release
+	phase   int   // Ordering within the versions leading up to a release
+	ordinal []int // Ordering within a phase: year, week and letter for weekly snapshots
+	old     string
+}
+
+// Phases of the versions leading up to a release, in order
+const (
+	minecraftPhaseSnapshot = iota
+	minecraftPhasePreRelease
+	minecraftPhaseReleaseCandidate
+	minecraftPhaseRelease
+	// Weekly snapshots newer than the bundled version list, after its last release
+	minecraftPhaseUnknownSnapshot
+)
+
+// minecraftRelease is a release from the bundled version list
+type minecraftRelease struct {
+	name          string
+	components    []int
+	firstSnapshot []int // Nil if no weekly snapshots led up to the release
+	lastSnapshot  []int
+}
+
+//go:embed minecraft_versions.txt
+var minecraftVersionList string
+
+var (
+	minecraftReleasePattern        = regexp.MustCompile(`^(\d+(?:\.\d+)+)$`)
+	minecraftDevelopmentPattern    = regexp.MustCompile(`^(?i)(\d+(?:\.\d+)+)(?:-| )(pre|rc|snapshot|pre-release)(?:-| )?(\d+)$`)
+	minecraftWeeklySnapshotPattern = regexp.MustCompile(`^(\d{2})w(\d{2})([a-z])$`)
+	minecraftOldPattern            = regexp.MustCompile(`^(rd|c|in|inf|a|b)-?(\d.*)$`)
+	minecraftOldTokenPattern       = regexp.MustCompile(`\d+|[^\d._\- ]+`)
+
+	minecraftOldEras  = []string{"rd", "c", "in", "inf", "a", "b"}
+	minecraftOldTypes = []MinecraftVersionType{
+		MinecraftPreClassic, MinecraftClassic, MinecraftIndev, MinecraftInfdev, MinecraftOldAlpha, MinecraftOldBeta,
+	}
+
+	minecraftReleases = sync.OnceValue(func() []minecraftRelease {
+		releases := make([]minecraftRelease, 0)
+		for _, line := range strings.Split(minecraftVersionList, "\n") {
+			fields := strings.Fields(line)
+			if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+				continue
+			}
+			release := minecraftRelease{name: fields[0], components: versionComponents(fields[0])}
+			if len(fields) == 3 {
+				release.firstSnapshot = weeklySnapshotOrdinal(fields[1])
+				release.lastSnapshot = weeklySnapshotOrdinal(fields[2])
+			}
+			releases = append(releases, release)
+		}
+		return releases
+	})
+)
+
+// ParseMinecraftVersion parses a release (1.20.1, 26.1), weekly snapshot (24w14a), pre-release (1.20.5-pre3,
+// "1.14 Pre-Release 2"), release candidate (1.21-rc1), new-style snapshot (26.1-snapshot-1), or an old
+// alpha, beta, infdev, indev or classic version (b1.7.3, a1.2.6, inf-20100618, in-20091223-2, c0.30_01c)
+func ParseMinecraftVersion(version string) (*MinecraftVersion, error) {
+	trimmed := strings.TrimSpace(version)
+	parsed := &MinecraftVersion{Raw: version, era: len(minecraftOldEras)}
+
+	if minecraftReleasePattern.MatchString(trimmed) {
+		parsed.Type = MinecraftRelease
+		parsed.Release = trimmed
+		parsed.target = versionComponents(trimmed)
+		parsed.phase = minecraftPhaseRelease
+		return parsed, nil
+	}
+
+	if match := minecraftDevelopmentPattern.FindStringSubmatch(trimmed); match != nil {
+		parsed.Release = match[1]
+		parsed.target = versionComponents(match[1])
+		parsed.Number, _ = strconv.Atoi(match[3])
+		parsed.ordinal = []int{parsed.Number}
+		switch strings.ToLower(match[2]) {
+		case "snapshot":
+			parsed.Type = MinecraftSnapshot
+			parsed.phase = minecraftPhaseSnapshot
+		case "rc":
+			parsed.Type = MinecraftReleaseCandidate
+			parsed.phase = minecraftPhaseReleaseCandidate
+		default:
+			parsed.Type = MinecraftPreRelease
+			parsed.phase = minecraftPhasePreRelease
+		}
+		return parsed, nil
+	}
+
+	if ordinal := weeklySnapshotOrdinal(trimmed); ordinal != nil {
+		parsed.Type = MinecraftSnapshot
+		parsed.ordinal = ordinal
+		parsed.phase = minecraftPhaseUnknownSnapshot
+		releases := minecraftReleases()
+		parsed.target = releases[len(releases)-1].components
+		// A snapshot leads up to the first release whose snapshots did not all come out before it
+		for _, release := range releases {
+			if release.lastSnapshot != nil && slices.Compare(ordinal, release.lastSnapshot) <= 0 {
+				parsed.Release = release.name
+				parsed.target = release.components
+				parsed.phase = minecraftPhaseSnapshot
+				break
+			}
+		}
+		return parsed, nil
+	}
+
+	if match := minecraftOldPattern.FindStringSubmatch(trimmed); match != nil {
+		parsed.era = slices.Index(minecraftOldEras, match[1])
+		parsed.Type = minecraftOldTypes[parsed.era]
+		parsed.old = match[2]
+		return parsed, nil
+	}
+
+	return nil, fmt.Errorf("invalid Minecraft version %q", version)
+}
+
+// MinecraftReleases returns every release in the bundled version list, oldest first
+func MinecraftReleases() []string {
+	names := make([]string, 0)
+	for _, release := range minecraftReleases() {
+		names = append(names, release.name)
+	}
+	return names
+}
+
+// versionComponents splits a dotted version into its numbers
+func versionComponents(version string) []int {
+	components := make([]int, 0)
+	for _, part := range strings.Split(version, ".") {
+		number, _ := strconv.Atoi(part)
+		components = append(components, number)
+	}
+	return components
+}
+
+// weeklySnapshotOrdinal returns the year, week and letter of a weekly snapshot, or nil if it is not one
+func weeklySnapshotOrdinal(version string) []int {
+	match := minecraftWeeklySnapshotPattern.FindStringSubmatch(version)
+	if match == nil {
+		return nil
+	}
+	year, _ := strconv.Atoi(match[1])
+	week, _ := strconv.Atoi(match[2])
+	return []int{year, week, int(match[3][0])}
+}
+
+// compareComponents compares dotted version numbers, treating missing components as 0
+func compareComponents(a []int, b []int) int {
+	for i := 0; i < max(len(a), len(b)); i++ {
+		var left, right int
+		if i < len(a) {
+			left = a[i]
+		}
+		if i < len(b) {
+			right = b[i]
+		}
+		if left != right {
+			return compareInts(left, right)
+		}
+	}
+	return 0
+}
+
+// compareOldVersions compares the number and letter runs of two old versions of the same era
+func compareOldVersions(a string, b string) int {
+	left, right := minecraftOldTokenPattern.FindAllString(a, -1), minecraftOldTokenPattern.FindAllString(b, -1)
+	for i := 0; i < min(len(left), len(right)); i++ {
+		leftNum, leftErr := strconv.Atoi(left[i])
+		rightNum, rightErr := strconv.Atoi(right[i])
+		var cmp int
+		switch {
+		case leftErr == nil && rightErr == nil:
+			cmp = compareInts(leftNum, rightNum)
+		case leftErr == nil:
+			cmp = 1
+		case rightErr == nil:
+			cmp = -1
+		default:
+			cmp = strings.Compare(left[i], right[i])
+		}
+		if cmp != 0 {
+			return cmp
+		}
+	}
+	return compareInts(len(left), len(right))
+}
+
+func (v *MinecraftVersion) String() string {
+	return v.Raw
+}
+
+// IsRelease reports whether the version is a full release
+func (v *MinecraftVersion) IsRelease() bool {
+	return v.Type == MinecraftRelease
+}
+
+// Compare orders two versions chronologically, returning -1, 0 or 1.
+// Snapshots, pre-releases and release candidates come before the release they lead up to.
+func (v *MinecraftVersion) Compare(other *MinecraftVersion) int {
+	if v.era != other.era {
+		return compareInts(v.era, other.era)
+	}
+	if v.era < len(minecraftOldEras) {
+		return compareOldVersions(v.old, other.old)
+	}
+	if cmp := compareComponents(v.target, other.target); cmp != 0 {
+		return cmp
+	}
+	if v.phase != other.phase {
+		return compareInts(v.phase, other.phase)
+	}
+	return compareInts(slices.Compare(v.ordinal, other.ordinal), 0)
+}
+
+// CompareMinecraftVersions parses and compares two versions
+func CompareMinecraftVersions(a string, b string) (int, error) {
+	left, err := ParseMinecraftVersion(a)
+	if err != nil {
+		return 0, err
+	}
+	right, err := ParseMinecraftVersion(b)
+	if err != nil {
+		return 0, err
+	}
+	return left.Compare(right), nil
+}
@@ -0,0 +1,62 @@
+package mcmodmeta_test
+
+import (
+	mcmodmeta "mc-mod-metadata/src"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMinecraftVersionOrdering(t *testing.T) {
+	ordered := []string{
+		"rd-132211", "c0.0.11a", "c0.30_01c", "in-20091223-2", "inf-20100618", "a1.0.4", "a1.2.6", "b1.7.3", "b1.8.1",
+		"1.0", "1.8.9", "1.12.2", "1.13", "1.14 Pre-Release 2", "1.14", "1.16.5",
+		"23w51a", "24w14a", "1.20.5-pre1", "1.20.5-pre3", "1.20.5-rc1", "1.20.5", "1.20.6",
+		"24w18a", "1.21-pre1", "1.21-rc1", "1.21", "1.21.11", "25w50a", "26.1-snapshot-1", "26.1-snapshot-2", "26.1-pre-1", "26.1-rc-1", "26.1", "26.1.1",
+	}
+	for i := 1; i < len(ordered); i++ {
+		cmp, err := mcmodmeta.CompareMinecraftVersions(ordered[i-1], ordered[i])
+		assert.Nil(t, err)
+		assert.Equal(t, -1, cmp, "%s < %s", ordered[i-1], ordered[i])
+		cmp, _ = mcmodmeta.CompareMinecraftVersions(ordered[i], ordered[i-1])
+		assert.Equal(t, 1, cmp, "%s > %s", ordered[i], ordered[i-1])
+	}
+
+	cmp, err := mcmodmeta.CompareMinecraftVersions("1.21", "1.21.0")
+	assert.Nil(t, err)
+	assert.Equal(t, 0, cmp)
+}
+
+func TestParseMinecraftVersion(t *testing.T) {
+	snapshot, err := mcmodmeta.ParseMinecraftVersion("24w14a")
+	assert.Nil(t, err)
+	assert.Equal(t, mcmodmeta.MinecraftSnapshot, snapshot.Type)
+	assert.Equal(t, "1.20.5", snapshot.Release)
+
+	pre, err := mcmodmeta.ParseMinecraftVersion("1.14 Pre-Release 2")
+	assert.Nil(t, err)
+	assert.Equal(t, mcmodmeta.MinecraftPreRelease, pre.Type)
+	assert.Equal(t, "1.14", pre.Release)
+	assert.Equal(t, 2, pre.Number)
+
+	rc, err := mcmodmeta.ParseMinecraftVersion("26.1-rc-2")
+	assert.Nil(t, err)
+	assert.Equal(t, mcmodmeta.MinecraftReleaseCandidate, rc.Type)
+	assert.Equal(t, "26.1", rc.Release)
+
+	beta, err := mcmodmeta.ParseMinecraftVersion("b1.7.3")
+	assert.Nil(t, err)
+	assert.Equal(t, mcmodmeta.MinecraftOldBeta, beta.Type)
+	assert.False(t, beta.IsRelease())
+
+	unknown, err := mcmodmeta.ParseMinecraftVersion("25w50a")
+	assert.Nil(t, err)
+	assert.Equal(t, "", unknown.Release)
+
+	_, err = mcmodmeta.ParseMinecraftVersion("Potato")
+	assert.NotNil(t, err)
+
+	releases := mcmodmeta.MinecraftReleases()
+	assert.Equal(t, "1.0", releases[0])
+	assert.Contains(t, releases, "1.20.1")
+}
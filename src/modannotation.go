@@ -0,0 +1,193 @@
+package mcmodmeta
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+// ForgeModAnnotation is the @Mod annotation on the main class of a Forge mod, which 1.7 to 1.12 mods
+// often use instead of a complete mcmod.info
+type ForgeModAnnotation struct {
+	Class                     string // Internal name of the annotated class
+	Path                      string // Path of the class file inside the jar
+	ModID                     string
+	Name                      string
+	Version                   string
+	Dependencies              string // e.g. required-after:forge@[14.23.5.2768,);after:jei
+	AcceptedMinecraftVersions string // A Maven version range, empty for the version Forge was built for
+	ClientSideOnly            bool
+	ServerSideOnly            bool
+	UseMetadata               bool // Whether mcmod.info takes precedence over the annotation
+}
+
+// forgeModAnnotationTypes are the @Mod descriptors of Forge 1.8 and later, and of 1.7's cpw.mods namespace
+var forgeModAnnotationTypes = []string{"Lnet/minecraftforge/fml/common/Mod;", "Lcpw/mods/fml/common/Mod;"}
+
+// forgeAnnotationDependencyPattern matches a single @Mod dependency, e.g. required-after:forge@[14.23,)
+var forgeAnnotationDependencyPattern = regexp.MustCompile(`^(required)?-?(after|before|client|server)?:([^@]+)(?:@(.+))?$`)
+
+// NewForgeModAnnotation reads the @Mod annotation of a class, returning nil if it has none
+func NewForgeModAnnotation(class *ClassFile) *ForgeModAnnotation {
+	for _, descriptor := range forgeModAnnotationTypes {
+		annotation, ok := class.Annotation(descriptor)
+		if !ok {
+			continue
+		}
+		return &ForgeModAnnotation{
+			Class:                     class.Name,
+			ModID:                     firstNonEmpty(annotation.String("modid"), annotation.String("value")),
+			Name:                      annotation.String("name"),
+			Version:                   annotation.String("version"),
+			Dependencies:              annotation.String("dependencies"),
+			AcceptedMinecraftVersions: annotation.String("acceptedMinecraftVersions"),
+			ClientSideOnly:            annotation.Bool("clientSideOnly"),
+			ServerSideOnly:            annotation.Bool("serverSideOnly"),
+			UseMetadata:               annotation.Bool("useMetadata"),
+		}
+	}
+	return nil
+}
+
+// ParsedDependencies converts the dependency string into dependency declarations. required- makes a
+// dependency mandatory, after and before set the load order, and client and server limit it to a side.
+func (a *ForgeModAnnotation) ParsedDependencies() []ModDependency {
+	deps := make([]ModDependency, 0)
+	for _, entry := range strings.Split(a.Dependencies, ";") {
+		match := forgeAnnotationDependencyPattern.FindStringSubmatch(strings.TrimSpace(entry))
+		if match == nil || match[3] == "*" {
+			continue
+		}
+		dep := ModDependency{ID: strings.TrimSpace(match[3]), VersionRange: match[4], Kind: DependencyOptional, Ordering: "NONE", Side: "BOTH"}
+		if match[1] != "" {
+			dep.Kind = DependencyRequired
+		}
+		switch match[2] {
+		case "after", "before":
+			dep.Ordering = strings.ToUpper(match[2])
+		case "client", "server":
+			dep.Side = strings.ToUpper(match[2])
+		}
+		deps = append(deps, dep)
+	}
+	return deps
+}
+
+// maxClassSize bounds the size of a class file read when looking for @Mod annotations. Real classes are far
+// smaller, since the constant pool and each method are limited to 65535 entries and bytes.
+const maxClassSize = 8 << 20
+
+// needsModAnnotations reports whether the jar looks like a pre-1.13 Forge mod whose descriptors may be
+// incomplete: it has no mods.toml, and has an mcmod.info or manifest attributes only legacy FML reads
+func needsModAnnotations(zipListing *zip.Reader, jar *JarMetadata) bool {
+	hasMcmodInfo := false
+	for _, file := range zipListing.File {
+		if file.Name == "META-INF/mods.toml" || file.Name == "META-INF/neoforge.mods.toml" {
+			return false
+		}
+		if file.Name == "mcmod.info" {
+			hasMcmodInfo = true
+		}
+	}
+	if hasMcmodInfo {
+		return true
+	}
+	manifest := jar.Manifest
+	return manifest != nil && (manifest.FMLCorePluginContainsFMLMod() || manifest.FMLAT() != "" || manifest.ForceLoadAsMod())
+}
+
+// classBytesFromFile reads a class file, refusing one larger than maxClassSize. The size in the zip entry's
+// header is checked first, and the data itself as it is read, since the header may lie.
+func classBytesFromFile(file *zip.File) ([]byte, error) {
+	tooLarge := fmt.Errorf("class file is not read, it is larger than %d bytes", maxClassSize)
+	if file.UncompressedSize64 > maxClassSize {
+		return nil, tooLarge
+	}
+
+	fileReader, err := file.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer fileReader.Close()
+
+	data, err := io.ReadAll(io.LimitReader(fileReader, maxClassSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxClassSize {
+		return nil, tooLarge
+	}
+	return data, nil
+}
+
+// readModAnnotations finds every class annotated with @Mod and records it in JarMetadata.ModAnnotations.
+// Each fills in what mcmod.info left empty for the same mod ID, or becomes a mod of its own when mcmod.info
+// does not declare it; an mcmod.info missing its modid is then reported as a warning rather than an error.
+func (s *jarScanner) readModAnnotations(zipListing *zip.Reader, placeholders map[string]string) []*ModMetadata {
+	mods := make([]*ModMetadata, 0)
+	for _, file := range zipListing.File {
+		if !strings.HasSuffix(file.Name, ".class") || strings.HasPrefix(file.Name, "META-INF/versions/") {
+			continue
+		}
+		data, err := classBytesFromFile(file)
+		if err != nil {
+			s.warn(file.Name, err.Error())
+			continue
+		}
+		// Only parse classes that mention the annotation at all
+		if !bytes.Contains(data, []byte("fml/common/Mod;")) {
+			continue
+		}
+		class, err := ParseClassFile(data)
+		if err != nil {
+			s.warn(file.Name, err.Error())
+			continue
+		}
+		annotation := NewForgeModAnnotation(class)
+		if annotation == nil || annotation.ModID == "" {
+			continue
+		}
+		annotation.Path = file.Name
+		s.jar.ModAnnotations = append(s.jar.ModAnnotations, annotation)
+
+		if existing := s.legacyForgeMod(annotation.ModID); existing != nil {
+			fillFromModAnnotation(existing, annotation, placeholders)
+			continue
+		}
+		mods = append(mods, newForgeAnnotationMetadata(annotation, file.Name))
+	}
+
+	if len(mods) > 0 {
+		s.downgradeMissingModIDs()
+	}
+	return mods
+}
+
+// downgradeMissingModIDs turns mcmod.info's missing modid errors into warnings, once @Mod annotations
+// have supplied the mods it failed to declare
+func (s *jarScanner) downgradeMissingModIDs() {
+	errs := make([]error, 0, len(s.jar.Errors))
+	for _, err := range s.jar.Errors {
+		var missing *MissingFieldError
+		if errors.As(err, &missing) && missing.Path == "mcmod.info" && missing.Field == "modid" {
+			s.warn(missing.Path, err.Error()+", read from @Mod annotations instead")
+			continue
+		}
+		errs = append(errs, err)
+	}
+	s.jar.Errors = errs
+}
+
+// legacyForgeMod returns the mod read from mcmod.info with the given ID, if any
+func (s *jarScanner) legacyForgeMod(id string) *ModMetadata {
+	for _, mod := range s.jar.Mods {
+		if _, ok := mod.Raw.(*ForgeLegacyMod); ok && mod.ID == id {
+			return mod
+		}
+	}
+	return nil
+}
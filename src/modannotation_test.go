@@ -0,0 +1,223 @@
+package mcmodmeta_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	mcmodmeta "mc-mod-metadata/src"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// classBuilder writes a minimal class file whose only content is a set of class annotations
+type classBuilder struct {
+	pool  bytes.Buffer
+	count uint16
+}
+
+func (b *classBuilder) entry(tag byte, data ...any) uint16 {
+	b.pool.WriteByte(tag)
+	for _, d := range data {
+		binary.Write(&b.pool, binary.BigEndian, d)
+	}
+	b.count++
+	index := b.count
+	if tag == 5 || tag == 6 {
+		b.count++
+	}
+	return index
+}
+
+func (b *classBuilder) utf8(s string) uint16 {
+	return b.entry(1, uint16(len(s)), []byte(s))
+}
+
+// buildAnnotatedClass builds a Java 8 class annotated with descriptor, with string and boolean element values
+func buildAnnotatedClass(name string, descriptor string, values map[string]any) []byte {
+	b := &classBuilder{}
+	b.entry(5, uint64(1)) // A long, to check that it takes two constant pool slots
+	thisClass := b.entry(7, b.utf8(name))
+	superClass := b.entry(7, b.utf8("java/lang/Object"))
+	attributeName := b.utf8("RuntimeVisibleAnnotations")
+
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var annotation bytes.Buffer
+	binary.Write(&annotation, binary.BigEndian, []uint16{1, b.utf8(descriptor), uint16(len(keys))})
+	for _, key := range keys {
+		binary.Write(&annotation, binary.BigEndian, b.utf8(key))
+		switch value := values[key].(type) {
+		case string:
+			annotation.WriteByte('s')
+			binary.Write(&annotation, binary.BigEndian, b.utf8(value))
+		case bool:
+			bit := uint32(0)
+			if value {
+				bit = 1
+			}
+			annotation.WriteByte('Z')
+			binary.Write(&annotation, binary.BigEndian, b.entry(3, bit))
+		}
+	}
+
+	var class bytes.Buffer
+	binary.Write(&class, binary.BigEndian, uint32(0xCAFEBABE))
+	binary.Write(&class, binary.BigEndian, []uint16{0, 52, b.count + 1})
+	class.Write(b.pool.Bytes())
+	binary.Write(&class, binary.BigEndian, []uint16{0x21, thisClass, superClass, 0, 0, 0, 1, attributeName})
+	binary.Write(&class, binary.BigEndian, uint32(annotation.Len()))
+	class.Write(annotation.Bytes())
+	return class.Bytes()
+}
+
+func TestParseClassFile(t *testing.T) {
+	data := buildAnnotatedClass("net/example/ExampleMod", "Lnet/minecraftforge/fml/common/Mod;", map[string]any{
+		"modid":          "examplemod",
+		"clientSideOnly": true,
+	})
+
+	class, err := mcmodmeta.ParseClassFile(data)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "net/example/ExampleMod", class.Name)
+	assert.Equal(t, uint16(52), class.MajorVersion)
+	assert.Equal(t, 8, class.JavaVersion())
+	annotation, ok := class.Annotation("Lnet/minecraftforge/fml/common/Mod;")
+	assert.True(t, ok)
+	assert.Equal(t, "examplemod", annotation.String("modid"))
+	assert.True(t, annotation.Bool("clientSideOnly"))
+
+	_, err = mcmodmeta.ParseClassFile(data[:len(data)-3])
+	assert.NotNil(t, err)
+	_, err = mcmodmeta.ParseClassFile([]byte("not a class"))
+	assert.NotNil(t, err)
+}
+
+func TestForgeModAnnotationFallback(t *testing.T) {
+	jar := mustReadJar(t, writeTestJar(t, map[string]string{
+		"mcmod.info": `[]`,
+		"net/example/ExampleMod.class": string(buildAnnotatedClass("net/example/ExampleMod", "Lnet/minecraftforge/fml/common/Mod;", map[string]any{
+			"modid":                     "examplemod",
+			"name":                      "Example Mod",
+			"version":                   "1.2.3",
+			"dependencies":              "required-after:forge@[14.23.5.2768,);after:jei;required-client:ctm@[1.0,);before:*",
+			"acceptedMinecraftVersions": "[1.12.2]",
+			"clientSideOnly":            true,
+		})),
+		"net/example/Helper.class": string(buildAnnotatedClass("net/example/Helper", "Ljava/lang/Deprecated;", map[string]any{})),
+	}))
+
+	assert.Equal(t, 1, len(jar.Mods))
+	mod := jar.Mods[0]
+	assert.Equal(t, "examplemod", mod.ID)
+	assert.Equal(t, "Example Mod", mod.Name)
+	assert.Equal(t, "1.2.3", mod.Version)
+	assert.Equal(t, mcmodmeta.SideClient, mod.Side)
+	assert.Equal(t, mcmodmeta.PlatformForge, mod.Platform)
+	assert.Equal(t, "net/example/ExampleMod.class", mod.Source)
+	assert.Equal(t, "net/example/ExampleMod", mod.Raw.(*mcmodmeta.ForgeModAnnotation).Class)
+	assert.Equal(t, []mcmodmeta.ModDependency{
+		{ID: "forge", VersionRange: "[14.23.5.2768,)", Kind: mcmodmeta.DependencyRequired, Ordering: "AFTER", Side: "BOTH"},
+		{ID: "jei", Kind: mcmodmeta.DependencyOptional, Ordering: "AFTER", Side: "BOTH"},
+		{ID: "ctm", VersionRange: "[1.0,)", Kind: mcmodmeta.DependencyRequired, Ordering: "NONE", Side: "CLIENT"},
+		{ID: "minecraft", VersionRange: "[1.12.2]", Kind: mcmodmeta.DependencyRequired},
+	}, mod.Dependencies)
+	assert.Empty(t, jar.Errors)
+	assert.Equal(t, []mcmodmeta.Warning{
+		{Path: "mcmod.info", Message: `mcmod.info: missing required field "modid", read from @Mod annotations instead`},
+	}, jar.Warnings)
+
+	compatibility := jar.MinecraftCompatibility()
+	assert.True(t, compatibility.Supports("1.12.2"))
+	assert.Equal(t, "net/example/ExampleMod.class acceptedMinecraftVersions", compatibility.Sources[0].Source)
+	assert.Equal(t, mcmodmeta.ConfidenceHigh, compatibility.Sources[0].Confidence)
+}
+
+func TestForgeModAnnotationFillsMcmodInfo(t *testing.T) {
+	jar := mustReadJar(t, writeTestJar(t, map[string]string{
+		"mcmod.info": `[{"modid": "examplemod", "name": "Example Mod", "version": "${version}"}]`,
+		"cpw/example/ExampleMod.class": string(buildAnnotatedClass("cpw/example/ExampleMod", "Lcpw/mods/fml/common/Mod;", map[string]any{
+			"modid":                     "examplemod",
+			"name":                      "Ignored Name",
+			"version":                   "1.0.0",
+			"acceptedMinecraftVersions": "[1.7.10]",
+		})),
+	}))
+
+	assert.Equal(t, 1, len(jar.Mods))
+	assert.Equal(t, "Example Mod", jar.Mods[0].Name)
+	assert.Equal(t, "1.0.0", jar.Mods[0].Version)
+	assert.Equal(t, "", jar.Mods[0].Raw.(*mcmodmeta.ForgeLegacyMod).MCVersion)
+	assert.Empty(t, jar.Errors)
+	assert.Empty(t, jar.Warnings)
+
+	compatibility := jar.MinecraftCompatibility()
+	assert.Equal(t, 1, len(compatibility.Sources))
+	assert.Equal(t, "cpw/example/ExampleMod.class acceptedMinecraftVersions", compatibility.Sources[0].Source)
+	assert.Equal(t, "examplemod", compatibility.Sources[0].Mod)
+	assert.True(t, compatibility.Supports("1.7.10"))
+	assert.False(t, compatibility.Supports("1.12.2"))
+}
+
+func TestForgeModAnnotationKeepsMcmodInfoVersion(t *testing.T) {
+	jar := mustReadJar(t, writeTestJar(t, map[string]string{
+		"mcmod.info": `[{"modid": "examplemod", "version": "${version}", "mcversion": "1.7.10"}]`,
+		"cpw/example/ExampleMod.class": string(buildAnnotatedClass("cpw/example/ExampleMod", "Lcpw/mods/fml/common/Mod;", map[string]any{
+			"modid":                     "examplemod",
+			"version":                   "1.0.0",
+			"acceptedMinecraftVersions": "[1.7.10]",
+		})),
+	}), mcmodmeta.WithPlaceholder("version", "2.0.0"))
+
+	assert.Equal(t, "2.0.0", jar.Mods[0].Version)
+	assert.Empty(t, jar.Warnings)
+
+	sources := jar.MinecraftCompatibility().Sources
+	assert.Equal(t, 2, len(sources))
+	assert.Equal(t, "cpw/example/ExampleMod.class acceptedMinecraftVersions", sources[0].Source)
+	assert.Equal(t, "mcmod.info mcversion", sources[1].Source)
+	assert.Equal(t, "1.7.10", sources[1].Declared)
+}
+
+func TestForgeModAnnotationNeedsForgeEvidence(t *testing.T) {
+	class := string(buildAnnotatedClass("net/example/ExampleMod", "Lnet/minecraftforge/fml/common/Mod;", map[string]any{
+		"modid":   "examplemod",
+		"version": "1.2.3",
+	}))
+
+	library := mustReadJar(t, writeTestJar(t, map[string]string{"net/example/ExampleMod.class": class}))
+	assert.Empty(t, library.Mods)
+	assert.Empty(t, library.ModAnnotations)
+
+	coremod := mustReadJar(t, writeTestJar(t, map[string]string{
+		"META-INF/MANIFEST.MF":         "Manifest-Version: 1.0\nFMLCorePlugin: net.example.CorePlugin\nFMLCorePluginContainsFMLMod: true\n",
+		"net/example/ExampleMod.class": class,
+	}))
+	assert.Equal(t, 1, len(coremod.Mods))
+	assert.Equal(t, "examplemod", coremod.Mods[0].ID)
+
+	transformer := mustReadJar(t, writeTestJar(t, map[string]string{
+		"META-INF/MANIFEST.MF":         "Manifest-Version: 1.0\nFMLAT: example_at.cfg\n",
+		"net/example/ExampleMod.class": class,
+	}))
+	assert.Equal(t, 1, len(transformer.Mods))
+}
+
+func TestForgeModAnnotationClassSizeLimit(t *testing.T) {
+	jar := mustReadJar(t, writeTestJar(t, map[string]string{
+		"mcmod.info":                 `[{"modid": "examplemod", "version": "1.0.0"}]`,
+		"net/example/Huge.class":     classHeader(8) + strings.Repeat("fml/common/Mod;", 600000),
+		"net/example/Ordinary.class": string(buildAnnotatedClass("net/example/Ordinary", "Ljava/lang/Deprecated;", map[string]any{})),
+	}))
+
+	assert.Equal(t, 1, len(jar.Mods))
+	assert.Equal(t, []mcmodmeta.Warning{
+		{Path: "net/example/Huge.class", Message: "class file is not read, it is larger than 8388608 bytes"},
+	}, jar.Warnings)
+}
@@ -0,0 +1,62 @@
+package mcmodmeta
+
+// Option configures how ReadJarFile reads a jar
+type Option func(*readOptions)
+
+// readOptions holds the settings applied by Option values
+type readOptions struct {
+	lenientJSON     bool
+	placeholders    map[string]string
+	maxNestingDepth int
+	maxNestedSize   int64
+}
+
+// Defaults for the limits on nested jars, which guard against jars that contain themselves and zip bombs
+const (
+	defaultMaxNestingDepth = 8
+	defaultMaxNestedSize   = 128 << 20
+)
+
+// WithLenientJSON normalises JSON descriptors with NormalizeJSON before decoding them,
+// recording every fix-up as a Warning on the JarMetadata
+func WithLenientJSON() Option {
+	return func(opts *readOptions) {
+		opts.lenientJSON = true
+	}
+}
+
+// WithPlaceholder supplies a value for a ${name} placeholder that cannot be derived from the jar itself,
+// such as global.mcVersion or global.forgeVersion
+func WithPlaceholder(name string, value string) Option {
+	return func(opts *readOptions) {
+		opts.placeholders[name] = value
+	}
+}
+
+// WithMaxNestingDepth sets how many levels of nested jars are read, 8 by default. Deeper jars are skipped with a Warning.
+func WithMaxNestingDepth(depth int) Option {
+	return func(opts *readOptions) {
+		opts.maxNestingDepth = depth
+	}
+}
+
+// WithMaxNestedSize sets the largest uncompressed size, in bytes, of a nested jar that is read into memory,
+// 128 MiB by default. Larger jars are skipped with a Warning.
+func WithMaxNestedSize(size int64) Option {
+	return func(opts *readOptions) {
+		opts.maxNestedSize = size
+	}
+}
+
+// newReadOptions applies opts over the defaults
+func newReadOptions(opts []Option) readOptions {
+	options := readOptions{
+		placeholders:    map[string]string{},
+		maxNestingDepth: defaultMaxNestingDepth,
+		maxNestedSize:   defaultMaxNestedSize,
+	}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return options
+}
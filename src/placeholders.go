@@ -0,0 +1,52 @@
+package mcmodmeta
+
+import (
+	"regexp"
+)
+
+// placeholderPattern matches ${name} style placeholders left behind by FML or an unprocessed Gradle build
+var placeholderPattern = regexp.MustCompile(`\$\{([^}]*)\}`)
+
+// fmlPlaceholders returns the values FML substitutes into mods.toml, given the jar's manifest
+func fmlPlaceholders(manifest *Manifest, extra map[string]string) map[string]string {
+	values := map[string]string{}
+	if manifest != nil && manifest.ImplementationVersion() != "" {
+		values["file.jarVersion"] = manifest.ImplementationVersion()
+	}
+	for key, value := range extra {
+		values[key] = value
+	}
+	return values
+}
+
+// substitutePlaceholders replaces every known ${name} in s and returns the names it could not resolve
+func substitutePlaceholders(s string, values map[string]string) (string, []string) {
+	unresolved := make([]string, 0)
+	replaced := placeholderPattern.ReplaceAllStringFunc(s, func(match string) string {
+		name := match[2 : len(match)-1]
+		if value, ok := values[name]; ok {
+			return value
+		}
+		unresolved = append(unresolved, match)
+		return match
+	})
+	return replaced, unresolved
+}
+
+// resolvePlaceholders substitutes placeholders in a mod's version and dependency ranges,
+// recording a warning for each one that stays unresolved
+func (s *jarScanner) resolvePlaceholders(mod *ModMetadata, values map[string]string) {
+	version, unresolved := substitutePlaceholders(mod.Version, values)
+	mod.Version = version
+	for _, placeholder := range unresolved {
+		s.warn(mod.Source, "unresolved placeholder "+placeholder+" in version of "+mod.ID)
+	}
+
+	for i, dep := range mod.Dependencies {
+		versionRange, unresolved := substitutePlaceholders(dep.VersionRange, values)
+		mod.Dependencies[i].VersionRange = versionRange
+		for _, placeholder := range unresolved {
+			s.warn(mod.Source, "unresolved placeholder "+placeholder+" in "+dep.ID+" version range of "+mod.ID)
+		}
+	}
+}
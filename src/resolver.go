@@ -0,0 +1,208 @@
+package mcmodmeta
+
+import (
+	"slices"
+	"strings"
+)
+
+type (
+	// ResolveEnvironment is the game and loader a mods folder is resolved against
+	ResolveEnvironment struct {
+		MinecraftVersion string
+		Loader           Platform
+		LoaderVersion    string
+	}
+
+	// ResolutionReport is the outcome of resolving the dependencies of a mods folder
+	ResolutionReport struct {
+		Unmet             []DependencyIssue // Required dependencies that are not installed
+		VersionMismatches []DependencyIssue // Dependencies that are installed, but not in a version the mod accepts
+		Incompatibilities []DependencyIssue // Incompatible or discouraged mods that are installed in the listed versions
+		SatisfiedOptional []DependencyIssue // Optional and recommended dependencies that are installed in a matching version
+		Unchecked         []DependencyIssue // Installed dependencies whose version range could not be evaluated
+	}
+
+	// DependencyIssue is a single dependency declaration of a mod and what was found for it
+	DependencyIssue struct {
+		Mod        string
+		Jar        string
+		Dependency ModDependency
+		Found      string // Version of the installed dependency, empty if it is not installed or has no known version
+	}
+
+	// installedMod is a mod ID available in the resolved environment
+	installedMod struct {
+		version string
+		jar     string
+	}
+)
+
+// ResolveDependencies evaluates every dependency declaration of the mods in jars that the given loader would load.
+// A jar shipping descriptors for several platforms only contributes the mods for env.Loader, with Quilt also
+// loading Fabric mods and Paper also loading Bukkit plugins. Minecraft itself and the loader are always installed.
+func ResolveDependencies(jars []*JarMetadata, env ResolveEnvironment) *ResolutionReport {
+	report := &ResolutionReport{
+		Unmet:             make([]DependencyIssue, 0),
+		VersionMismatches: make([]DependencyIssue, 0),
+		Incompatibilities: make([]DependencyIssue, 0),
+		SatisfiedOptional: make([]DependencyIssue, 0),
+		Unchecked:         make([]DependencyIssue, 0),
+	}
+
+	installed := map[string][]installedMod{}
+	for id, version := range builtinMods(env) {
+		installed[id] = append(installed[id], installedMod{version: version})
+	}
+
+	type loadedMod struct {
+		mod *ModMetadata
+		jar string
+	}
+	loaded := make([]loadedMod, 0)
+	for _, jar := range jars {
+		for _, mod := range loadedMods(jar, env.Loader) {
+			loaded = append(loaded, loadedMod{mod: mod, jar: jar.Path})
+			installed[mod.ID] = append(installed[mod.ID], installedMod{version: mod.Version, jar: jar.Path})
+			for _, provided := range modProvides(mod) {
+				installed[provided.ID] = append(installed[provided.ID], installedMod{version: firstNonEmpty(provided.Version, mod.Version), jar: jar.Path})
+			}
+		}
+	}
+
+	for _, entry := range loaded {
+		for _, dep := range entry.mod.Dependencies {
+			issue := DependencyIssue{Mod: entry.mod.ID, Jar: entry.jar, Dependency: dep}
+			candidates := installed[dep.ID]
+
+			if len(candidates) == 0 {
+				if dep.Kind == DependencyRequired {
+					report.Unmet = append(report.Unmet, issue)
+				}
+				continue
+			}
+
+			matched, unchecked := false, false
+			for _, candidate := range candidates {
+				satisfied, checked := versionSatisfies(entry.mod.Platform, dep.VersionRange, candidate.version)
+				if !checked {
+					unchecked = true
+					issue.Found = candidate.version
+				} else if satisfied {
+					matched = true
+					issue.Found = candidate.version
+					break
+				}
+			}
+			if !matched && !unchecked {
+				issue.Found = candidates[0].version
+			}
+
+			switch {
+			case unchecked && !matched:
+				report.Unchecked = append(report.Unchecked, issue)
+			case dep.Kind == DependencyIncompatible || dep.Kind == DependencyDiscouraged:
+				if matched {
+					report.Incompatibilities = append(report.Incompatibilities, issue)
+				}
+			case !matched:
+				report.VersionMismatches = append(report.VersionMismatches, issue)
+			case dep.Kind != DependencyRequired:
+				report.SatisfiedOptional = append(report.SatisfiedOptional, issue)
+			}
+		}
+	}
+	return report
+}
+
+// loadedMods returns the mods of a jar that the given loader would load
+func loadedMods(jar *JarMetadata, loader Platform) []*ModMetadata {
+	switch {
+	case loader == PlatformQuilt && !jar.Supports(PlatformQuilt):
+		return jar.ModsFor(PlatformFabric)
+	case loader == PlatformPaper && !jar.Supports(PlatformPaper):
+		return jar.ModsFor(PlatformBukkit)
+	}
+	return jar.ModsFor(loader)
+}
+
+// builtinMods returns the mod IDs the game and loader provide themselves
+func builtinMods(env ResolveEnvironment) map[string]string {
+	mods := map[string]string{}
+	switch env.Loader {
+	case PlatformFabric:
+		mods["minecraft"] = env.MinecraftVersion
+		mods["fabricloader"] = env.LoaderVersion
+		mods["java"] = ""
+	case PlatformQuilt:
+		mods["minecraft"] = env.MinecraftVersion
+		mods["quilt_loader"] = env.LoaderVersion
+		mods["fabricloader"] = ""
+		mods["java"] = ""
+	case PlatformForge:
+		mods["minecraft"] = env.MinecraftVersion
+		mods["forge"] = env.LoaderVersion
+	case PlatformNeoForge:
+		mods["minecraft"] = env.MinecraftVersion
+		mods["neoforge"] = env.LoaderVersion
+	case PlatformSponge:
+		mods["minecraft"] = env.MinecraftVersion
+		mods["spongeapi"] = env.LoaderVersion
+	case PlatformVelocity:
+		mods["velocity"] = env.LoaderVersion
+	}
+	return mods
+}
+
+// modProvides returns the extra IDs a mod declares that it satisfies
+func modProvides(mod *ModMetadata) []QuiltProvides {
+	provides := make([]QuiltProvides, 0)
+	switch raw := mod.Raw.(type) {
+	case *FabricMod:
+		for _, id := range raw.Provides {
+			provides = append(provides, QuiltProvides{ID: id})
+		}
+	case *QuiltMod:
+		provides = append(provides, raw.QuiltLoader.Provides...)
+	case *BukkitPlugin:
+		for _, id := range raw.Provides {
+			provides = append(provides, QuiltProvides{ID: id})
+		}
+	case *PaperPlugin:
+		for _, id := range raw.Provides {
+			provides = append(provides, QuiltProvides{ID: id})
+		}
+	}
+	return provides
+}
+
+// versionSatisfies reports whether version is within versionRange, written in the syntax of the given platform.
+// checked is false when the range or version could not be evaluated.
+func versionSatisfies(platform Platform, versionRange string, version string) (satisfied bool, checked bool) {
+	versionRange = strings.TrimSpace(versionRange)
+	if versionRange == "" || versionRange == "*" {
+		return true, true
+	}
+	if version == "" {
+		return false, false
+	}
+
+	if platform == PlatformFabric || platform == PlatformQuilt {
+		predicate, err := ParseFabricVersionPredicate(versionRange)
+		if err != nil {
+			return false, false
+		}
+		return predicate.Test(version), true
+	}
+	if platform == PlatformForge || platform == PlatformNeoForge || platform == PlatformSponge {
+		mavenRange, err := ParseMavenVersionRange(versionRange)
+		if err != nil {
+			return false, false
+		}
+		return mavenRange.Contains(version), true
+	}
+
+	if slices.Contains(strings.Split(versionRange, " || "), version) {
+		return true, true
+	}
+	return false, false
+}
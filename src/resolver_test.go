@@ -0,0 +1,110 @@
+package mcmodmeta_test
+
+import (
+	mcmodmeta "mc-mod-metadata/src"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolveDependencies(t *testing.T) {
+	jars := []*mcmodmeta.JarMetadata{
+		mustReadJar(t, writeTestJar(t, map[string]string{
+			"fabric.mod.json": `{
+  "schemaVersion": 1,
+  "id": "taterlib",
+  "version": "0.1.0",
+  "depends": {"minecraft": "1.20.1", "fabricloader": "*", "fabric-api": "*", "missing": "*"},
+  "suggests": {"luckperms": "*", "notinstalled": "*"},
+  "breaks": {"badmod": "*", "oldmod": "1.0.0"}
+}`,
+			"META-INF/mods.toml": "modLoader = \"javafml\"\nloaderVersion = \"[47,)\"\nlicense = \"MIT\"\n[[mods]]\nmodId = \"taterlib\"\n" +
+				"[[dependencies.taterlib]]\nmodId = \"forgeonly\"\nmandatory = true\n",
+		})),
+		mustReadJar(t, writeTestJar(t, map[string]string{
+			"fabric.mod.json": `{"schemaVersion": 1, "id": "fabric-api", "version": "0.90.0", "depends": {"minecraft": "1.20.2"}}`,
+		})),
+		mustReadJar(t, writeTestJar(t, map[string]string{
+			"quilt.mod.json": `{"schema_version": 1, "quilt_loader": {"id": "qsl", "version": "1.0.0", "provides": [{"id": "luckperms", "version": "5.4"}]}}`,
+		})),
+		mustReadJar(t, writeTestJar(t, map[string]string{
+			"fabric.mod.json": `{"schemaVersion": 1, "id": "badmod", "version": "2.0.0"}`,
+		})),
+		mustReadJar(t, writeTestJar(t, map[string]string{
+			"fabric.mod.json": `{"schemaVersion": 1, "id": "oldmod", "version": "2.0.0", "depends": {"taterlib": ">=0.2.0"}}`,
+		})),
+	}
+
+	report := mcmodmeta.ResolveDependencies(jars, mcmodmeta.ResolveEnvironment{
+		MinecraftVersion: "1.20.1",
+		Loader:           mcmodmeta.PlatformFabric,
+		LoaderVersion:    "0.15.0",
+	})
+
+	assert.Equal(t, 1, len(report.Unmet))
+	assert.Equal(t, "taterlib", report.Unmet[0].Mod)
+	assert.Equal(t, "missing", report.Unmet[0].Dependency.ID)
+
+	assert.Equal(t, 2, len(report.VersionMismatches))
+	assert.Equal(t, "fabric-api", report.VersionMismatches[0].Mod)
+	assert.Equal(t, "minecraft", report.VersionMismatches[0].Dependency.ID)
+	assert.Equal(t, "1.20.1", report.VersionMismatches[0].Found)
+	assert.Equal(t, "oldmod", report.VersionMismatches[1].Mod)
+	assert.Equal(t, "taterlib", report.VersionMismatches[1].Dependency.ID)
+	assert.Equal(t, "0.1.0", report.VersionMismatches[1].Found)
+
+	assert.Equal(t, 1, len(report.Incompatibilities))
+	assert.Equal(t, "badmod", report.Incompatibilities[0].Dependency.ID)
+	assert.Equal(t, "2.0.0", report.Incompatibilities[0].Found)
+
+	assert.Equal(t, 0, len(report.SatisfiedOptional))
+
+	assert.Equal(t, 0, len(report.Unchecked))
+
+	quilt := mcmodmeta.ResolveDependencies(jars, mcmodmeta.ResolveEnvironment{
+		MinecraftVersion: "1.20.1",
+		Loader:           mcmodmeta.PlatformQuilt,
+	})
+
+	assert.Equal(t, 1, len(quilt.SatisfiedOptional))
+	assert.Equal(t, "luckperms", quilt.SatisfiedOptional[0].Dependency.ID)
+	assert.Equal(t, "5.4", quilt.SatisfiedOptional[0].Found)
+}
+
+func TestResolveForgeDependencies(t *testing.T) {
+	jars := []*mcmodmeta.JarMetadata{
+		mustReadJar(t, writeTestJar(t, map[string]string{
+			"META-INF/mods.toml": "modLoader = \"javafml\"\nloaderVersion = \"[47,)\"\nlicense = \"MIT\"\n[[mods]]\nmodId = \"taterlib\"\nversion = \"1.0.0\"\n" +
+				"[[dependencies.taterlib]]\nmodId = \"forge\"\nmandatory = true\nversionRange = \"[47,)\"\n" +
+				"[[dependencies.taterlib]]\nmodId = \"minecraft\"\nmandatory = true\nversionRange = \"[1.20.2,1.21)\"\n",
+		})),
+	}
+
+	report := mcmodmeta.ResolveDependencies(jars, mcmodmeta.ResolveEnvironment{
+		MinecraftVersion: "1.20.1",
+		Loader:           mcmodmeta.PlatformForge,
+		LoaderVersion:    "47.2.0",
+	})
+
+	assert.Equal(t, 0, len(report.Unmet))
+	assert.Equal(t, 0, len(report.Unchecked))
+	assert.Equal(t, 1, len(report.VersionMismatches))
+	assert.Equal(t, "minecraft", report.VersionMismatches[0].Dependency.ID)
+	assert.Equal(t, "1.20.1", report.VersionMismatches[0].Found)
+}
+
+func TestResolveFabricProvides(t *testing.T) {
+	jars := []*mcmodmeta.JarMetadata{
+		mustReadJar(t, writeTestJar(t, map[string]string{
+			"fabric.mod.json": `{"schemaVersion": 1, "id": "taterlib", "version": "1.0.0", "provides": ["tater_lib"]}`,
+		})),
+		mustReadJar(t, writeTestJar(t, map[string]string{
+			"fabric.mod.json": `{"schemaVersion": 1, "id": "taterutils", "version": "1.0.0", "depends": {"tater_lib": ">=1.0.0"}}`,
+		})),
+	}
+
+	report := mcmodmeta.ResolveDependencies(jars, mcmodmeta.ResolveEnvironment{Loader: mcmodmeta.PlatformFabric})
+
+	assert.Equal(t, 0, len(report.Unmet))
+	assert.Equal(t, 0, len(report.VersionMismatches))
+}
@@ -1,27 +1,220 @@
 package mcmodmeta
 
 import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
 	"github.com/BurntSushi/toml"
 	"github.com/goccy/go-json"
 	"gopkg.in/yaml.v3"
 )
 
-// BukkitPlugin is a struct that represents the plugin.yml file of a Bukkit plugin
-type BukkitPlugin struct {
-	Name           string   `yaml:"name"`
-	Version        string   `yaml:"version"`
-	Author         string   `yaml:"author"`
-	Authors        []string `yaml:"authors"`
-	Description    string   `yaml:"description"`
-	Website        string   `yaml:"website"`
-	Main           string   `yaml:"main"`
-	Depend         []string `yaml:"depend"`
-	Depends        []string `yaml:"depends"`
-	SoftDepend     []string `yaml:"softdepend"`
-	SoftDepends    []string `yaml:"softdepends"`
-	LoadBefore     []string `yaml:"loadbefore"`
-	Load           string   `yaml:"load"`
-	FoliaSupported bool     `yaml:"folia-supported"`
+type (
+	// BukkitPlugin is a struct that represents the plugin.yml file of a Bukkit plugin
+	BukkitPlugin struct {
+		Name              string                      `yaml:"name"`
+		Version           string                      `yaml:"version"`
+		Author            string                      `yaml:"author"`
+		Authors           []string                    `yaml:"authors"`
+		Contributors      []string                    `yaml:"contributors"`
+		Description       string                      `yaml:"description"`
+		Website           string                      `yaml:"website"`
+		Main              string                      `yaml:"main"`
+		APIVersion        string                      `yaml:"api-version"`
+		Prefix            string                      `yaml:"prefix"`
+		Provides          []string                    `yaml:"provides"`
+		Libraries         []string                    `yaml:"libraries"` // Maven coordinates the server downloads for the plugin
+		Depend            []string                    `yaml:"depend"`
+		Depends           []string                    `yaml:"depends"`
+		SoftDepend        []string                    `yaml:"softdepend"`
+		SoftDepends       []string                    `yaml:"softdepends"`
+		LoadBefore        []string                    `yaml:"loadbefore"`
+		Load              BukkitLoadOrder             `yaml:"load"`
+		Commands          map[string]BukkitCommand    `yaml:"commands"`
+		Permissions       map[string]BukkitPermission `yaml:"permissions"`
+		DefaultPermission BukkitPermissionDefault     `yaml:"default-permission"`
+		FoliaSupported    bool                        `yaml:"folia-supported"`
+	}
+
+	// BukkitCommand represents an entry of the commands section of the plugin.yml file
+	BukkitCommand struct {
+		Description       string     `yaml:"description"`
+		Aliases           StringList `yaml:"aliases"`
+		Permission        string     `yaml:"permission"`
+		PermissionMessage string     `yaml:"permission-message"`
+		Usage             string     `yaml:"usage"`
+	}
+
+	// BukkitPermission represents an entry of the permissions section of the plugin.yml file
+	BukkitPermission struct {
+		Description string                   `yaml:"description"`
+		Default     BukkitPermissionDefault  `yaml:"default"` // Empty when not set, in which case default-permission applies
+		Children    BukkitPermissionChildren `yaml:"children"`
+
+		// Children defined in place rather than as true or false, keyed by node. Bukkit registers each as a
+		// permission of its own, defaulting to its parent's default.
+		InlineChildren map[string]BukkitPermission `yaml:"-"`
+	}
+
+	// BukkitPermissionChildren maps child permission nodes to whether they are granted or revoked by the parent
+	BukkitPermissionChildren map[string]bool
+
+	// BukkitLoadOrder is the server startup phase a Bukkit plugin is loaded in
+	BukkitLoadOrder string
+
+	// BukkitPermissionDefault is who a Bukkit permission is granted to by default
+	BukkitPermissionDefault string
+)
+
+const (
+	BukkitLoadStartup   BukkitLoadOrder = "STARTUP"
+	BukkitLoadPostWorld BukkitLoadOrder = "POSTWORLD"
+
+	BukkitPermissionTrue  BukkitPermissionDefault = "true"
+	BukkitPermissionFalse BukkitPermissionDefault = "false"
+	BukkitPermissionOp    BukkitPermissionDefault = "op"
+	BukkitPermissionNotOp BukkitPermissionDefault = "not op"
+)
+
+var (
+	// nonWordPattern matches the characters Bukkit strips before looking up enum names
+	nonWordPattern = regexp.MustCompile(`\W`)
+
+	// permissionDefaultPattern matches the characters Bukkit strips from a lowercased permission default
+	permissionDefaultPattern = regexp.MustCompile(`[^a-z!]`)
+)
+
+func (l *BukkitLoadOrder) UnmarshalYAML(value *yaml.Node) error {
+	switch BukkitLoadOrder(strings.ToUpper(nonWordPattern.ReplaceAllString(value.Value, ""))) {
+	case BukkitLoadStartup:
+		*l = BukkitLoadStartup
+	case BukkitLoadPostWorld:
+		*l = BukkitLoadPostWorld
+	default:
+		return fmt.Errorf("line %d: invalid load order %q, expected STARTUP or POSTWORLD", value.Line, value.Value)
+	}
+	return nil
+}
+
+func (d *BukkitPermissionDefault) UnmarshalYAML(value *yaml.Node) error {
+	// The aliases Bukkit's PermissionDefault accepts, after it strips everything but letters and '!'
+	switch permissionDefaultPattern.ReplaceAllString(strings.ToLower(value.Value), "") {
+	case "true":
+		*d = BukkitPermissionTrue
+	case "false":
+		*d = BukkitPermissionFalse
+	case "op", "isop", "operator", "isoperator", "admin", "isadmin":
+		*d = BukkitPermissionOp
+	case "!op", "notop", "!operator", "notoperator", "!admin", "notadmin":
+		*d = BukkitPermissionNotOp
+	default:
+		return fmt.Errorf("line %d: invalid permission default %q", value.Line, value.Value)
+	}
+	return nil
+}
+
+func (p *BukkitPermission) UnmarshalYAML(value *yaml.Node) error {
+	type plain BukkitPermission
+	if err := value.Decode((*plain)(p)); err != nil {
+		return err
+	}
+	if value.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	for i := 0; i+1 < len(value.Content); i += 2 {
+		if value.Content[i].Value != "children" || value.Content[i+1].Kind != yaml.MappingNode {
+			continue
+		}
+		children := value.Content[i+1].Content
+		for j := 0; j+1 < len(children); j += 2 {
+			name, node := children[j], children[j+1]
+			if node.Kind != yaml.MappingNode {
+				continue
+			}
+			var child BukkitPermission
+			if err := node.Decode(&child); err != nil {
+				return err
+			}
+			if child.Default == "" {
+				child.Default = p.Default
+			}
+			if p.InlineChildren == nil {
+				p.InlineChildren = map[string]BukkitPermission{}
+			}
+			p.InlineChildren[name.Value] = child
+		}
+	}
+	return nil
+}
+
+// registerInlinePermissions adds every inline child permission, recursively, alongside the permissions
+// declared at the top level, as Bukkit registers them. A node that is already declared keeps its own definition.
+func registerInlinePermissions(permissions map[string]BukkitPermission) {
+	pending := make([]BukkitPermission, 0, len(permissions))
+	for _, name := range sortedKeys(permissions) {
+		pending = append(pending, permissions[name])
+	}
+	for len(pending) > 0 {
+		perm := pending[0]
+		pending = pending[1:]
+		for _, name := range sortedKeys(perm.InlineChildren) {
+			if _, ok := permissions[name]; ok {
+				continue
+			}
+			permissions[name] = perm.InlineChildren[name]
+			pending = append(pending, perm.InlineChildren[name])
+		}
+	}
+}
+
+func (c *BukkitPermissionChildren) UnmarshalYAML(value *yaml.Node) error {
+	children := BukkitPermissionChildren{}
+	switch value.Kind {
+	case yaml.SequenceNode:
+		// A plain list grants every child
+		for _, child := range value.Content {
+			children[child.Value] = true
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(value.Content); i += 2 {
+			name, child := value.Content[i], value.Content[i+1]
+			if child.Kind == yaml.MappingNode {
+				// An inline child permission definition is granted by its parent
+				children[name.Value] = true
+				continue
+			}
+			var granted bool
+			if err := child.Decode(&granted); err != nil {
+				return err
+			}
+			children[name.Value] = granted
+		}
+	default:
+		return fmt.Errorf("line %d: permission children must be a list or a map", value.Line)
+	}
+	*c = children
+	return nil
+}
+
+// EffectiveDefault returns who the named permission is granted to by default,
+// falling back to default-permission and then to Bukkit's own default of op
+func (p *BukkitPlugin) EffectiveDefault(permission string) BukkitPermissionDefault {
+	return effectivePermissionDefault(p.Permissions, p.DefaultPermission, permission)
+}
+
+// effectivePermissionDefault resolves a permission's default for plugin.yml and paper-plugin.yml alike
+func effectivePermissionDefault(permissions map[string]BukkitPermission, fallback BukkitPermissionDefault, permission string) BukkitPermissionDefault {
+	if perm, ok := permissions[permission]; ok && perm.Default != "" {
+		return perm.Default
+	}
+	if fallback != "" {
+		return fallback
+	}
+	return BukkitPermissionOp
 }
 
 // NewBukkitPlugin creates a new BukkitPlugin struct from the plugin.yml file
@@ -31,6 +224,73 @@ func NewBukkitPlugin(pluginYML string) (*BukkitPlugin, error) {
 	if err != nil {
 		return nil, err
 	}
+	registerInlinePermissions(plugin.Permissions)
+	return plugin, nil
+}
+
+type (
+	// PaperPlugin is a struct that represents the paper-plugin.yml file of a Paper plugin
+	PaperPlugin struct {
+		Name               string            `yaml:"name"`
+		Version            string            `yaml:"version"`
+		Main               string            `yaml:"main"`
+		Description        string            `yaml:"description"`
+		Author             string            `yaml:"author"`
+		Authors            []string          `yaml:"authors"`
+		Contributors       []string          `yaml:"contributors"`
+		Website            string            `yaml:"website"`
+		APIVersion         string            `yaml:"api-version"`
+		Bootstrapper       string            `yaml:"bootstrapper"`
+		Loader             string            `yaml:"loader"`
+		Load               BukkitLoadOrder   `yaml:"load"`
+		Prefix             string            `yaml:"prefix"`
+		Provides           []string          `yaml:"provides"`
+		HasOpenClassloader bool              `yaml:"has-open-classloader"`
+		FoliaSupported     bool              `yaml:"folia-supported"`
+		Dependencies       PaperDependencies `yaml:"dependencies"`
+
+		Permissions       map[string]BukkitPermission `yaml:"permissions"`
+		DefaultPermission BukkitPermissionDefault     `yaml:"default-permission"`
+	}
+
+	// PaperDependencies represents the dependencies section of the paper-plugin.yml file
+	PaperDependencies struct {
+		Bootstrap map[string]PaperDependency `yaml:"bootstrap"` // Dependencies available to the bootstrapper and loader
+		Server    map[string]PaperDependency `yaml:"server"`    // Dependencies available once the server has started
+	}
+
+	// PaperDependency is a single entry of the bootstrap or server dependency maps
+	PaperDependency struct {
+		Load          string `yaml:"load"`           // BEFORE, AFTER or OMIT: when the dependency loads relative to this plugin
+		Required      bool   `yaml:"required"`       // Defaults to true
+		JoinClasspath bool   `yaml:"join-classpath"` // Defaults to true
+	}
+)
+
+func (d *PaperDependency) UnmarshalYAML(value *yaml.Node) error {
+	type plain PaperDependency
+	dep := plain{Load: "OMIT", Required: true, JoinClasspath: true}
+	if err := value.Decode(&dep); err != nil {
+		return err
+	}
+	*d = PaperDependency(dep)
+	return nil
+}
+
+// EffectiveDefault returns who the named permission is granted to by default,
+// falling back to default-permission and then to op
+func (p *PaperPlugin) EffectiveDefault(permission string) BukkitPermissionDefault {
+	return effectivePermissionDefault(p.Permissions, p.DefaultPermission, permission)
+}
+
+// NewPaperPlugin creates a new PaperPlugin struct from the paper-plugin.yml file
+func NewPaperPlugin(paperPluginYML string) (*PaperPlugin, error) {
+	plugin := &PaperPlugin{}
+	err := yaml.Unmarshal([]byte(paperPluginYML), plugin)
+	if err != nil {
+		return nil, err
+	}
+	registerInlinePermissions(plugin.Permissions)
 	return plugin, nil
 }
 
@@ -47,7 +307,7 @@ type BungeeCordPlugin struct {
 	Depends     []string `yaml:"depends"`
 	SoftDepend  []string `yaml:"softdepend"`
 	SoftDepends []string `yaml:"softdepends"`
-	LoadBefore  []string `yaml:"loadbefore"`
+	LoadBefore  []string `yaml:"loadbefore"` // Often copied from plugin.yml, but ignored by BungeeCord
 }
 
 // NewBungeeCordPlugin creates a new BungeeCordPlugin struct from the bungee.yml/plugin.yml file
@@ -70,13 +330,13 @@ type (
 		Version string `json:"version"`
 
 		// Optional fields - Mod Loading
-		Environment string              `json:"environment"` // client, server, *. can also be a list, will ignore for this implementation
-		EntryPoints map[string][]string `json:"entrypoints"`
+		Environment StringList                    `json:"environment"` // client, server or *, as a single value or a list
+		EntryPoints map[string][]FabricEntrypoint `json:"entrypoints"`
 		Jars        []struct {
 			File string `json:"file"`
 		} `json:"jars"`
 		LanguageAdapters map[string]string `json:"languageAdapters"`
-		Mixins           []any             `json:"mixins"` // Can be a list of strings or {config: string, environment: string}
+		Mixins           []FabricMixin     `json:"mixins"`
 		AccessWidener    string            `json:"accessWidener"`
 
 		// Optional fields - Dependency Resolution
@@ -85,6 +345,7 @@ type (
 		Suggests   map[string]any `json:"suggests"`
 		Conflicts  map[string]any `json:"conflicts"`
 		Breaks     map[string]any `json:"breaks"`
+		Provides   []string       `json:"provides"` // Alternative IDs the mod can be depended on by
 
 		// Optional fields - Metadata
 		Name         string                   `json:"name"`
@@ -92,8 +353,11 @@ type (
 		Authors      []any                    `json:"authors"` // The spec is not enforced, so it can be anything or a FabricPerson
 		Contributors []any                    `json:"contributors"`
 		Contact      FabricContactInformation `json:"contact"`
-		License      string                   `json:"license"` // Can also be a list, will ignore for this implementation
-		Icon         string                   `json:"icon"`    // Can also be a map, will ignore for this implementation
+		License      StringList               `json:"license"` // SPDX identifiers, as a single value or a list
+		Icon         FabricIcon               `json:"icon"`
+
+		// Custom fields, keyed by the namespace of the mod or tool that reads them, decoded by CustomValue
+		Custom map[string]json.RawMessage `json:"custom"`
 	}
 
 	// FabricPerson - author or contributor
@@ -110,31 +374,453 @@ type (
 		Issues   string `json:"issues"`
 		Sources  string `json:"sources"`
 	}
+
+	// FabricEntrypoint is an entrypoint class, method or field, written as a string or an object naming its language adapter
+	FabricEntrypoint struct {
+		Adapter string `json:"adapter"`
+		Value   string `json:"value"`
+	}
+
+	// FabricMixin is a mixin config, written as a path or an object limiting it to an environment
+	FabricMixin struct {
+		Config      string `json:"config"`
+		Environment string `json:"environment"` // client, server or *
+	}
+
+	// FabricIcon is the mod's icon, written as a single path or a map of paths keyed by their width in pixels
+	FabricIcon struct {
+		Path  string
+		Sizes map[string]string
+	}
 )
 
-// NewFabricMod creates a new FabricMod struct from the fabric.mod.json file
+func (e *FabricEntrypoint) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err == nil {
+		*e = FabricEntrypoint{Adapter: "default", Value: value}
+		return nil
+	}
+	type plain FabricEntrypoint
+	if err := json.Unmarshal(data, (*plain)(e)); err != nil {
+		return err
+	}
+	if e.Adapter == "" {
+		e.Adapter = "default"
+	}
+	return nil
+}
+
+func (m *FabricMixin) UnmarshalJSON(data []byte) error {
+	var config string
+	if err := json.Unmarshal(data, &config); err == nil {
+		*m = FabricMixin{Config: config, Environment: "*"}
+		return nil
+	}
+	type plain FabricMixin
+	if err := json.Unmarshal(data, (*plain)(m)); err != nil {
+		return err
+	}
+	if m.Environment == "" {
+		m.Environment = "*"
+	}
+	return nil
+}
+
+func (i *FabricIcon) UnmarshalJSON(data []byte) error {
+	var path string
+	if err := json.Unmarshal(data, &path); err == nil {
+		*i = FabricIcon{Path: path}
+		return nil
+	}
+	var sizes map[string]string
+	if err := json.Unmarshal(data, &sizes); err != nil {
+		return err
+	}
+	*i = FabricIcon{Sizes: sizes}
+	return nil
+}
+
+// Best returns the path of the smallest icon at least size pixels wide, or the largest icon if none is.
+// A single icon path is returned whatever its size.
+func (i FabricIcon) Best(size int) string {
+	if i.Path != "" || len(i.Sizes) == 0 {
+		return i.Path
+	}
+	best, bestWidth := "", 0
+	for key, path := range i.Sizes {
+		width, err := strconv.Atoi(key)
+		if err != nil {
+			continue
+		}
+		switch {
+		case best == "",
+			bestWidth < size && width > bestWidth,
+			width >= size && width < bestWidth:
+			best, bestWidth = path, width
+		}
+	}
+	return best
+}
+
+// NewFabricMod creates a new FabricMod struct from the fabric.mod.json file.
+// Legacy schemaVersion 0 documents, which omit schemaVersion, are upgraded to the schemaVersion 1 layout.
 func NewFabricMod(fabricModJSON string) (*FabricMod, error) {
-	mod := &FabricMod{}
-	err := json.Unmarshal([]byte(fabricModJSON), mod)
-	if err != nil {
+	header := struct {
+		SchemaVersion int `json:"schemaVersion"`
+	}{}
+	if err := json.Unmarshal([]byte(fabricModJSON), &header); err != nil {
 		return nil, err
 	}
-	// Now to convert the authors and contributors to FabricPerson structs
-	// The common case is that they are strings
-	// If they are not strings, they are probably already FabricPerson structs
-	for i, author := range mod.Authors {
-		if authorStr, ok := author.(string); ok {
-			mod.Authors[i] = FabricPerson{Name: authorStr}
+
+	var mod *FabricMod
+	switch header.SchemaVersion {
+	case 0:
+		legacy := &FabricModV0{}
+		if err := json.Unmarshal([]byte(fabricModJSON), legacy); err != nil {
+			return nil, err
+		}
+		mod = legacy.Upgrade()
+	case 1:
+		mod = &FabricMod{}
+		if err := json.Unmarshal([]byte(fabricModJSON), mod); err != nil {
+			return nil, err
 		}
-		if authorMap, ok := author.(map[string]interface{}); ok {
-			authorStruct := FabricPerson{}
-			mapJson, _ := json.Marshal(authorMap)
-			json.Unmarshal(mapJson, &authorStruct)
-			mod.Authors[i] = authorStruct
+	default:
+		return nil, fmt.Errorf("unsupported schemaVersion %d", header.SchemaVersion)
+	}
+	// Authors and contributors are usually plain names, and otherwise probably FabricPerson objects
+	normalizeFabricPeople(mod.Authors)
+	normalizeFabricPeople(mod.Contributors)
 
+	return mod, nil
+}
+
+type (
+	// FabricModV0 is the legacy schemaVersion 0 layout of fabric.mod.json, used before Fabric Loader 0.4
+	FabricModV0 struct {
+		ID      string `json:"id"`
+		Version string `json:"version"`
+
+		Side         string         `json:"side"` // client, server or universal
+		Initializer  string         `json:"initializer"`
+		Initializers []string       `json:"initializers"`
+		Mixins       FabricMixinsV0 `json:"mixins"`
+		Requires     map[string]any `json:"requires"`
+		Recommends   map[string]any `json:"recommends"`
+		Conflicts    map[string]any `json:"conflicts"`
+		Name         string         `json:"name"`
+		Description  string         `json:"description"`
+		Authors      []any          `json:"authors"` // Names, "Name <email> (website)" strings or FabricPerson objects
+		Contributors []any          `json:"contributors"`
+		Links        FabricLinksV0  `json:"links"`
+		License      string         `json:"license"`
+	}
+
+	// FabricMixinsV0 lists mixin configs by the environment they apply to
+	FabricMixinsV0 struct {
+		Client StringList `json:"client"`
+		Common StringList `json:"common"`
+		Server StringList `json:"server"`
+	}
+
+	// FabricLinksV0 is a homepage URL, or an object of homepage, issues and sources URLs
+	FabricLinksV0 struct {
+		HomePage string `json:"homepage"`
+		Issues   string `json:"issues"`
+		Sources  string `json:"sources"`
+	}
+)
+
+// fabricPersonV0Pattern matches a schemaVersion 0 person string, "Name <email> (website)"
+var fabricPersonV0Pattern = regexp.MustCompile(`^([^<(]*?)\s*(?:<([^>]*)>)?\s*(?:\(([^)]*)\))?$`)
+
+func (l *FabricLinksV0) UnmarshalJSON(data []byte) error {
+	var homepage string
+	if err := json.Unmarshal(data, &homepage); err == nil {
+		*l = FabricLinksV0{HomePage: homepage}
+		return nil
+	}
+	type plain FabricLinksV0
+	return json.Unmarshal(data, (*plain)(l))
+}
+
+// Upgrade converts the document to the schemaVersion 1 layout, the way Fabric Loader reads it:
+// requires becomes depends, conflicts becomes breaks, side becomes environment, initializers become main
+// entrypoints, mixins are tagged with their environment and links become contact information
+func (m *FabricModV0) Upgrade() *FabricMod {
+	mod := &FabricMod{
+		SchemaVersion: 0,
+		ID:            m.ID,
+		Version:       m.Version,
+		EntryPoints:   map[string][]FabricEntrypoint{},
+		Mixins:        make([]FabricMixin, 0),
+		Depends:       m.Requires,
+		Recommends:    m.Recommends,
+		Breaks:        m.Conflicts,
+		Name:          m.Name,
+		Description:   m.Description,
+		Authors:       upgradeFabricPeopleV0(m.Authors),
+		Contributors:  upgradeFabricPeopleV0(m.Contributors),
+		Contact:       FabricContactInformation{HomePage: m.Links.HomePage, Issues: m.Links.Issues, Sources: m.Links.Sources},
+	}
+
+	switch m.Side {
+	case "client", "server":
+		mod.Environment = StringList{m.Side}
+	default:
+		mod.Environment = StringList{"*"}
+	}
+
+	for _, initializer := range mergeLists([]string{m.Initializer}, m.Initializers) {
+		mod.EntryPoints["main"] = append(mod.EntryPoints["main"], FabricEntrypoint{Adapter: "default", Value: initializer})
+	}
+
+	for _, group := range []struct {
+		configs     StringList
+		environment string
+	}{{m.Mixins.Client, "client"}, {m.Mixins.Common, "*"}, {m.Mixins.Server, "server"}} {
+		for _, config := range group.configs {
+			mod.Mixins = append(mod.Mixins, FabricMixin{Config: config, Environment: group.environment})
+		}
+	}
+
+	if m.License != "" {
+		mod.License = StringList{m.License}
+	}
+	return mod
+}
+
+// upgradeFabricPeopleV0 splits "Name <email> (website)" person strings into FabricPerson structs
+func upgradeFabricPeopleV0(people []any) []any {
+	upgraded := make([]any, 0, len(people))
+	for _, person := range people {
+		str, ok := person.(string)
+		if !ok {
+			upgraded = append(upgraded, person)
+			continue
+		}
+		match := fabricPersonV0Pattern.FindStringSubmatch(strings.TrimSpace(str))
+		if match == nil {
+			upgraded = append(upgraded, FabricPerson{Name: str})
+			continue
+		}
+		upgraded = append(upgraded, FabricPerson{
+			Name:    match[1],
+			Contact: FabricContactInformation{Email: match[2], HomePage: match[3]},
+		})
+	}
+	return upgraded
+}
+
+// normalizeFabricPeople converts every name or person object in the list to a FabricPerson in place
+func normalizeFabricPeople(people []any) {
+	for i, person := range people {
+		if name, ok := person.(string); ok {
+			people[i] = FabricPerson{Name: name}
+		}
+		if personMap, ok := person.(map[string]interface{}); ok {
+			personStruct := FabricPerson{}
+			mapJson, _ := json.Marshal(personMap)
+			json.Unmarshal(mapJson, &personStruct)
+			people[i] = personStruct
 		}
 	}
+}
+
+type (
+	// QuiltMod is a struct that represents the quilt.mod.json file of a Quilt mod (schema_version 1)
+	QuiltMod struct {
+		SchemaVersion int            `json:"schema_version"`
+		QuiltLoader   QuiltLoader    `json:"quilt_loader"`
+		Mixin         StringList     `json:"mixin"`
+		AccessWidener StringList     `json:"access_widener"`
+		Minecraft     QuiltMinecraft `json:"minecraft"`
+	}
+
+	// QuiltLoader represents the quilt_loader section of the quilt.mod.json file
+	QuiltLoader struct {
+		// Mandatory fields
+		Group   string `json:"group"`
+		ID      string `json:"id"`
+		Version string `json:"version"`
+
+		// Optional fields
+		Provides             []QuiltProvides             `json:"provides"`
+		Entrypoints          map[string]QuiltEntrypoints `json:"entrypoints"`
+		Plugins              QuiltEntrypoints            `json:"plugins"`
+		Jars                 []string                    `json:"jars"`
+		LanguageAdapters     map[string]string           `json:"language_adapters"`
+		Depends              []QuiltDependency           `json:"depends"`
+		Breaks               []QuiltDependency           `json:"breaks"`
+		LoadType             string                      `json:"load_type"` // always, if_possible or if_required
+		Repositories         []string                    `json:"repositories"`
+		IntermediateMappings string                      `json:"intermediate_mappings"`
+		Metadata             QuiltMetadata               `json:"metadata"`
+	}
+
+	// QuiltMetadata represents the quilt_loader.metadata section of the quilt.mod.json file
+	QuiltMetadata struct {
+		Name         string            `json:"name"`
+		Description  string            `json:"description"`
+		Contributors map[string]any    `json:"contributors"` // Name to role, or to a list of roles
+		Contact      map[string]string `json:"contact"`      // homepage, issues, sources, email, or anything else
+		License      QuiltLicenses     `json:"license"`
+		Icon         any               `json:"icon"` // A path, or a map of sizes to paths
+	}
+
+	// QuiltMinecraft represents the minecraft section of the quilt.mod.json file
+	QuiltMinecraft struct {
+		Environment string `json:"environment"` // *, client or dedicated_server
+	}
+
+	// QuiltProvides is a mod ID (and optionally version) that a Quilt mod also satisfies
+	QuiltProvides struct {
+		ID      string `json:"id"`
+		Version string `json:"version"`
+	}
+
+	// QuiltEntrypoint is a single entrypoint, either a bare class reference or an object with an adapter
+	QuiltEntrypoint struct {
+		Adapter string `json:"adapter"`
+		Value   string `json:"value"`
+	}
+
+	// QuiltEntrypoints is one or more entrypoints declared under the same key
+	QuiltEntrypoints []QuiltEntrypoint
+
+	// QuiltDependency is a dependency or breaks declaration.
+	// Declared as a bare mod ID, an object, or an array meaning any one of the listed dependencies.
+	QuiltDependency struct {
+		ID       string            `json:"id"`
+		Versions StringList        `json:"versions"` // Any of the listed version predicates
+		Reason   string            `json:"reason"`
+		Optional bool              `json:"optional"`
+		Unless   *QuiltDependency  `json:"unless"`
+		AnyOf    []QuiltDependency `json:"-"`
+	}
+
+	// QuiltLicense is a license declared as an SPDX identifier or an object
+	QuiltLicense struct {
+		Name        string `json:"name"`
+		ID          string `json:"id"`
+		URL         string `json:"url"`
+		Description string `json:"description"`
+	}
+
+	// QuiltLicenses is one or more licenses, written as a single entry or a list
+	QuiltLicenses []QuiltLicense
+
+	// StringList is a JSON value that may be written as a single string or a list of strings
+	StringList []string
+)
+
+func (l *StringList) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		*l = StringList{value.Value}
+		return nil
+	}
+	var list []string
+	if err := value.Decode(&list); err != nil {
+		return err
+	}
+	*l = list
+	return nil
+}
+
+func (l *StringList) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*l = StringList{single}
+		return nil
+	}
+	var list []string
+	if err := json.Unmarshal(data, &list); err != nil {
+		return err
+	}
+	*l = list
+	return nil
+}
+
+func (p *QuiltProvides) UnmarshalJSON(data []byte) error {
+	var id string
+	if err := json.Unmarshal(data, &id); err == nil {
+		*p = QuiltProvides{ID: id}
+		return nil
+	}
+	type plain QuiltProvides
+	return json.Unmarshal(data, (*plain)(p))
+}
+
+func (e *QuiltEntrypoint) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err == nil {
+		*e = QuiltEntrypoint{Value: value}
+		return nil
+	}
+	type plain QuiltEntrypoint
+	return json.Unmarshal(data, (*plain)(e))
+}
+
+func (e *QuiltEntrypoints) UnmarshalJSON(data []byte) error {
+	var list []QuiltEntrypoint
+	if err := json.Unmarshal(data, &list); err == nil {
+		*e = list
+		return nil
+	}
+	var single QuiltEntrypoint
+	if err := json.Unmarshal(data, &single); err != nil {
+		return err
+	}
+	*e = QuiltEntrypoints{single}
+	return nil
+}
+
+func (d *QuiltDependency) UnmarshalJSON(data []byte) error {
+	var id string
+	if err := json.Unmarshal(data, &id); err == nil {
+		*d = QuiltDependency{ID: id}
+		return nil
+	}
+	var anyOf []QuiltDependency
+	if err := json.Unmarshal(data, &anyOf); err == nil {
+		*d = QuiltDependency{AnyOf: anyOf}
+		return nil
+	}
+	type plain QuiltDependency
+	return json.Unmarshal(data, (*plain)(d))
+}
+
+func (l *QuiltLicense) UnmarshalJSON(data []byte) error {
+	var id string
+	if err := json.Unmarshal(data, &id); err == nil {
+		*l = QuiltLicense{ID: id}
+		return nil
+	}
+	type plain QuiltLicense
+	return json.Unmarshal(data, (*plain)(l))
+}
+
+func (l *QuiltLicenses) UnmarshalJSON(data []byte) error {
+	var list []QuiltLicense
+	if err := json.Unmarshal(data, &list); err == nil {
+		*l = list
+		return nil
+	}
+	var single QuiltLicense
+	if err := json.Unmarshal(data, &single); err != nil {
+		return err
+	}
+	*l = QuiltLicenses{single}
+	return nil
+}
 
+// NewQuiltMod creates a new QuiltMod struct from the quilt.mod.json file
+func NewQuiltMod(quiltModJSON string) (*QuiltMod, error) {
+	mod := &QuiltMod{}
+	err := json.Unmarshal([]byte(quiltModJSON), mod)
+	if err != nil {
+		return nil, err
+	}
 	return mod, nil
 }
 
@@ -160,10 +846,42 @@ type ForgeLegacyMod struct {
 	Dependants               []string `json:"dependants"`
 }
 
-// NewForgeLegacyMod creates a new ForgeLegacyMod struct from the mcmod.info file
+// ForgeLegacyModList is the {"modListVersion": 2, "modList": [...]} wrapper some mcmod.info files use
+type ForgeLegacyModList struct {
+	ModListVersion int               `json:"modListVersion"`
+	ModList        []*ForgeLegacyMod `json:"modList"`
+}
+
+// NewForgeLegacyMod creates a new ForgeLegacyMod struct for every mod in the mcmod.info file.
+// Accepts the plain list format, the modListVersion 2 wrapper and a bare single mod object.
 func NewForgeLegacyMod(mcmodInfoJSON string) ([]*ForgeLegacyMod, error) {
+	trimmed := bytes.TrimSpace([]byte(mcmodInfoJSON))
+	if len(trimmed) > 0 && trimmed[0] == '{' {
+		fields := map[string]json.RawMessage{}
+		err := json.Unmarshal(trimmed, &fields)
+		if err != nil {
+			return nil, err
+		}
+
+		if _, ok := fields["modList"]; ok {
+			modList := &ForgeLegacyModList{}
+			err := json.Unmarshal(trimmed, modList)
+			if err != nil {
+				return nil, err
+			}
+			return modList.ModList, nil
+		}
+
+		mod := &ForgeLegacyMod{}
+		err = json.Unmarshal(trimmed, mod)
+		if err != nil {
+			return nil, err
+		}
+		return []*ForgeLegacyMod{mod}, nil
+	}
+
 	mod := []*ForgeLegacyMod{}
-	err := json.Unmarshal([]byte(mcmodInfoJSON), &mod)
+	err := json.Unmarshal(trimmed, &mod)
 	if err != nil {
 		return nil, err
 	}
@@ -198,11 +916,13 @@ type (
 
 		// Optional non-mod-specific properties
 		ShowAsResourcePack bool `toml:"showAsResourcePack"`
+		ClientSideOnly     bool `toml:"clientSideOnly"`
 		Properties         map[string]interface{}
 		IssueTrackerURL    string `toml:"issueTrackerURL"`
 
 		Mods         []ForgeModInfo
 		Dependencies map[string][]ForgeModDependency
+		Features     map[string]map[string]interface{} `toml:"features"` // Keyed by mod ID, e.g. javaVersion = "[17,)"
 	}
 
 	// ModInfo represents the [[mods]] section of the mods.toml file
@@ -263,6 +983,7 @@ type (
 			Config string `toml:"config"`
 		} `toml:"mixins"`
 		Dependencies map[string][]NeoForgeModDependency
+		Features     map[string]map[string]interface{} `toml:"features"` // Keyed by mod ID, e.g. javaVersion = "[21,)"
 	}
 
 	// NeoForgeModInfo is a struct that represents a mod in a NeoForge mod
@@ -409,3 +1130,40 @@ func NewVelocityPlugin(velocityJSON string) (*VelocityPlugin, error) {
 	}
 	return plugin, nil
 }
+
+type (
+	// JarJarMetadata is the META-INF/jarjar/metadata.json file listing the jars a Forge or NeoForge mod embeds
+	JarJarMetadata struct {
+		Jars []JarJarEntry `json:"jars"`
+	}
+
+	// JarJarEntry is a single embedded jar, which the loader only uses if no other mod ships a newer version
+	JarJarEntry struct {
+		Identifier   JarJarIdentifier `json:"identifier"`
+		Version      JarJarVersion    `json:"version"`
+		Path         string           `json:"path"` // Path of the embedded jar inside the outer jar
+		IsObfuscated bool             `json:"isObfuscated"`
+	}
+
+	// JarJarIdentifier is the Maven coordinate of an embedded jar
+	JarJarIdentifier struct {
+		Group    string `json:"group"`
+		Artifact string `json:"artifact"`
+	}
+
+	// JarJarVersion is the Maven version range the outer mod accepts, and the version actually embedded
+	JarJarVersion struct {
+		Range           string `json:"range"`
+		ArtifactVersion string `json:"artifactVersion"`
+	}
+)
+
+// NewJarJarMetadata creates a new JarJarMetadata struct from the META-INF/jarjar/metadata.json file
+func NewJarJarMetadata(metadataJSON string) (*JarJarMetadata, error) {
+	metadata := &JarJarMetadata{}
+	err := json.Unmarshal([]byte(metadataJSON), metadata)
+	if err != nil {
+		return nil, err
+	}
+	return metadata, nil
+}
@@ -394,3 +394,390 @@ func TestVelocityDeserialization(t *testing.T) {
 	assert.Equal(t, 0, len(velocityPlugin.Dependencies))
 	assert.Equal(t, "dev.neuralnexus.taterloader.platforms.VelocityLoaderPlugin", velocityPlugin.Main)
 }
+
+func TestForgeLegacyModListWrapper(t *testing.T) {
+	forgeLegacyString := `{
+  "modListVersion": 2,
+  "modList": [{
+    "modid": "taterlib",
+    "name": "TaterLib",
+    "version": "0.1.0",
+    "mcversion": "1.12.2",
+    "authorList": ["p0t4t0sandwich"]
+  }, {
+    "modid": "taterlibcore",
+    "name": "TaterLib Core",
+    "version": "0.1.0",
+    "requiredMods": ["taterlib"]
+  }]
+}`
+
+	forgeLegacyMods, err := mcmodmeta.NewForgeLegacyMod(forgeLegacyString)
+
+	assert.Nil(t, err)
+
+	assert.Equal(t, 2, len(forgeLegacyMods))
+	assert.Equal(t, "taterlib", forgeLegacyMods[0].ModID)
+	assert.Equal(t, "1.12.2", forgeLegacyMods[0].MCVersion)
+	assert.Equal(t, "taterlibcore", forgeLegacyMods[1].ModID)
+	assert.Equal(t, "taterlib", forgeLegacyMods[1].RequiredMods[0])
+}
+
+func TestForgeLegacyModSingleObject(t *testing.T) {
+	forgeLegacyString := `
+{
+  "modid": "taterlib",
+  "name": "TaterLib",
+  "version": "0.1.0"
+}`
+
+	forgeLegacyMods, err := mcmodmeta.NewForgeLegacyMod(forgeLegacyString)
+
+	assert.Nil(t, err)
+
+	assert.Equal(t, 1, len(forgeLegacyMods))
+	assert.Equal(t, "taterlib", forgeLegacyMods[0].ModID)
+	assert.Equal(t, "TaterLib", forgeLegacyMods[0].Name)
+}
+
+func TestQuiltDeserialization(t *testing.T) {
+	quiltString := `{
+  "schema_version": 1,
+  "quilt_loader": {
+    "group": "dev.neuralnexus",
+    "id": "taterlib",
+    "version": "0.1.0",
+    "provides": ["taterapi", {"id": "taterlib_core", "version": "0.1.0"}],
+    "entrypoints": {
+      "init": "dev.neuralnexus.taterloader.platforms.QuiltLoaderPlugin",
+      "client_init": [{"adapter": "kotlin", "value": "dev.neuralnexus.taterlib.Client"}]
+    },
+    "jars": ["META-INF/jars/thing-0.1.0.jar"],
+    "depends": [
+      "quilt_loader",
+      {"id": "minecraft", "versions": ">=1.20"},
+      {"id": "luckperms", "optional": true, "reason": "permissions"},
+      [{"id": "fabric-api"}, {"id": "quilted_fabric_api"}]
+    ],
+    "breaks": [{"id": "oldmod", "versions": ["<1.0", "=1.2.0"]}],
+    "metadata": {
+      "name": "TaterLib",
+      "description": "some words",
+      "contributors": {"p0t4t0sandwich": "Owner"},
+      "contact": {"homepage": "https://some.homepage", "sources": "https://some.repo"},
+      "license": "GPL-3.0",
+      "icon": "assets/taterlib/icon.png"
+    }
+  },
+  "mixin": "taterlib.mixins.json",
+  "access_widener": ["taterlib.accesswidener"],
+  "minecraft": {"environment": "dedicated_server"}
+}`
+
+	quiltMod, err := mcmodmeta.NewQuiltMod(quiltString)
+
+	assert.Nil(t, err)
+
+	assert.Equal(t, 1, quiltMod.SchemaVersion)
+	assert.Equal(t, "dev.neuralnexus", quiltMod.QuiltLoader.Group)
+	assert.Equal(t, "taterlib", quiltMod.QuiltLoader.ID)
+	assert.Equal(t, "0.1.0", quiltMod.QuiltLoader.Version)
+	assert.Equal(t, []mcmodmeta.QuiltProvides{{ID: "taterapi"}, {ID: "taterlib_core", Version: "0.1.0"}}, quiltMod.QuiltLoader.Provides)
+	assert.Equal(t, "dev.neuralnexus.taterloader.platforms.QuiltLoaderPlugin", quiltMod.QuiltLoader.Entrypoints["init"][0].Value)
+	assert.Equal(t, "kotlin", quiltMod.QuiltLoader.Entrypoints["client_init"][0].Adapter)
+	assert.Equal(t, []string{"META-INF/jars/thing-0.1.0.jar"}, quiltMod.QuiltLoader.Jars)
+	assert.Equal(t, 4, len(quiltMod.QuiltLoader.Depends))
+	assert.Equal(t, "quilt_loader", quiltMod.QuiltLoader.Depends[0].ID)
+	assert.Equal(t, mcmodmeta.StringList{">=1.20"}, quiltMod.QuiltLoader.Depends[1].Versions)
+	assert.Equal(t, true, quiltMod.QuiltLoader.Depends[2].Optional)
+	assert.Equal(t, 2, len(quiltMod.QuiltLoader.Depends[3].AnyOf))
+	assert.Equal(t, mcmodmeta.StringList{"<1.0", "=1.2.0"}, quiltMod.QuiltLoader.Breaks[0].Versions)
+	assert.Equal(t, "TaterLib", quiltMod.QuiltLoader.Metadata.Name)
+	assert.Equal(t, "Owner", quiltMod.QuiltLoader.Metadata.Contributors["p0t4t0sandwich"])
+	assert.Equal(t, "https://some.repo", quiltMod.QuiltLoader.Metadata.Contact["sources"])
+	assert.Equal(t, mcmodmeta.QuiltLicenses{{ID: "GPL-3.0"}}, quiltMod.QuiltLoader.Metadata.License)
+	assert.Equal(t, mcmodmeta.StringList{"taterlib.mixins.json"}, quiltMod.Mixin)
+	assert.Equal(t, mcmodmeta.StringList{"taterlib.accesswidener"}, quiltMod.AccessWidener)
+	assert.Equal(t, "dedicated_server", quiltMod.Minecraft.Environment)
+}
+
+func TestPaperDeserialization(t *testing.T) {
+	paperString := `name: TaterLib
+version: 0.1.0
+main: dev.neuralnexus.taterloader.platforms.PaperLoaderPlugin
+description: some words
+author: p0t4t0sandwich
+website: https://some.url
+api-version: '1.20'
+bootstrapper: dev.neuralnexus.taterloader.platforms.PaperBootstrap
+loader: dev.neuralnexus.taterloader.platforms.PaperLoader
+load: STARTUP
+folia-supported: true
+dependencies:
+  bootstrap:
+    LuckPerms:
+      load: BEFORE
+      required: false
+  server:
+    LuckPerms:
+      load: BEFORE
+      required: false
+      join-classpath: false
+    ProtocolLib: {}
+`
+
+	paperPlugin, err := mcmodmeta.NewPaperPlugin(paperString)
+
+	assert.Nil(t, err)
+
+	assert.Equal(t, "TaterLib", paperPlugin.Name)
+	assert.Equal(t, "0.1.0", paperPlugin.Version)
+	assert.Equal(t, "dev.neuralnexus.taterloader.platforms.PaperLoaderPlugin", paperPlugin.Main)
+	assert.Equal(t, "p0t4t0sandwich", paperPlugin.Author)
+	assert.Equal(t, "1.20", paperPlugin.APIVersion)
+	assert.Equal(t, "dev.neuralnexus.taterloader.platforms.PaperBootstrap", paperPlugin.Bootstrapper)
+	assert.Equal(t, "dev.neuralnexus.taterloader.platforms.PaperLoader", paperPlugin.Loader)
+	assert.Equal(t, mcmodmeta.BukkitLoadStartup, paperPlugin.Load)
+	assert.Equal(t, true, paperPlugin.FoliaSupported)
+	assert.Equal(t, mcmodmeta.PaperDependency{Load: "BEFORE", Required: false, JoinClasspath: true}, paperPlugin.Dependencies.Bootstrap["LuckPerms"])
+	assert.Equal(t, mcmodmeta.PaperDependency{Load: "BEFORE", Required: false, JoinClasspath: false}, paperPlugin.Dependencies.Server["LuckPerms"])
+	assert.Equal(t, mcmodmeta.PaperDependency{Load: "OMIT", Required: true, JoinClasspath: true}, paperPlugin.Dependencies.Server["ProtocolLib"])
+}
+
+func TestBukkitFullDeserialization(t *testing.T) {
+	bukkitString := `name: TaterLib
+version: 0.1.0
+main: dev.neuralnexus.taterloader.platforms.BukkitLoaderPlugin
+api-version: 1.20
+prefix: Tater
+load: startup
+provides: [ TaterAPI ]
+libraries:
+  - com.google.code.gson:gson:2.10.1
+default-permission: not op
+commands:
+  tater:
+    description: Main command
+    aliases: [ tl, taterlib ]
+    permission: taterlib.command
+    permission-message: No potatoes for you
+    usage: /<command> [reload]
+  potato:
+    aliases: spud
+permissions:
+  taterlib.*:
+    description: Everything
+    default: op
+    children:
+      taterlib.command: true
+      taterlib.reload: false
+      taterlib.inline:
+        description: Declared inline
+  taterlib.command:
+    default: true
+    children: [ taterlib.use ]
+  taterlib.reload:
+    description: Reload the plugin
+`
+
+	bukkitPlugin, err := mcmodmeta.NewBukkitPlugin(bukkitString)
+
+	assert.Nil(t, err)
+
+	assert.Equal(t, "1.20", bukkitPlugin.APIVersion)
+	assert.Equal(t, "Tater", bukkitPlugin.Prefix)
+	assert.Equal(t, mcmodmeta.BukkitLoadStartup, bukkitPlugin.Load)
+	assert.Equal(t, []string{"TaterAPI"}, bukkitPlugin.Provides)
+	assert.Equal(t, []string{"com.google.code.gson:gson:2.10.1"}, bukkitPlugin.Libraries)
+	assert.Equal(t, mcmodmeta.BukkitPermissionNotOp, bukkitPlugin.DefaultPermission)
+
+	assert.Equal(t, 2, len(bukkitPlugin.Commands))
+	assert.Equal(t, "Main command", bukkitPlugin.Commands["tater"].Description)
+	assert.Equal(t, mcmodmeta.StringList{"tl", "taterlib"}, bukkitPlugin.Commands["tater"].Aliases)
+	assert.Equal(t, "taterlib.command", bukkitPlugin.Commands["tater"].Permission)
+	assert.Equal(t, "No potatoes for you", bukkitPlugin.Commands["tater"].PermissionMessage)
+	assert.Equal(t, "/<command> [reload]", bukkitPlugin.Commands["tater"].Usage)
+	assert.Equal(t, mcmodmeta.StringList{"spud"}, bukkitPlugin.Commands["potato"].Aliases)
+
+	assert.Equal(t, 4, len(bukkitPlugin.Permissions))
+	assert.Equal(t, mcmodmeta.BukkitPermissionOp, bukkitPlugin.Permissions["taterlib.*"].Default)
+	assert.Equal(t, mcmodmeta.BukkitPermissionChildren{
+		"taterlib.command": true,
+		"taterlib.reload":  false,
+		"taterlib.inline":  true,
+	}, bukkitPlugin.Permissions["taterlib.*"].Children)
+	assert.Equal(t, mcmodmeta.BukkitPermissionChildren{"taterlib.use": true}, bukkitPlugin.Permissions["taterlib.command"].Children)
+	assert.Equal(t, mcmodmeta.BukkitPermissionTrue, bukkitPlugin.EffectiveDefault("taterlib.command"))
+	assert.Equal(t, mcmodmeta.BukkitPermissionNotOp, bukkitPlugin.EffectiveDefault("taterlib.reload"))
+}
+
+func TestBukkitInlinePermissions(t *testing.T) {
+	bukkitString := `name: TaterLib
+version: 0.1.0
+main: dev.neuralnexus.taterlib.bukkit.BukkitTaterLibPlugin
+default-permission: not op
+permissions:
+  a.*:
+    default: op
+    children:
+      a.use: true
+      a.inline:
+        description: Declared inline
+        default: true
+        children:
+          a.nested:
+            description: Nested inline
+          a.use: false
+      a.inherited:
+        description: Takes the parent's default
+  a.use:
+    description: Declared at the top level
+  b.*:
+    children:
+      b.inline: {}
+`
+
+	bukkitPlugin, err := mcmodmeta.NewBukkitPlugin(bukkitString)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 7, len(bukkitPlugin.Permissions))
+	assert.Equal(t, "Declared inline", bukkitPlugin.Permissions["a.inline"].Description)
+	assert.Equal(t, mcmodmeta.BukkitPermissionChildren{"a.nested": true, "a.use": false}, bukkitPlugin.Permissions["a.inline"].Children)
+	assert.Equal(t, mcmodmeta.BukkitPermissionTrue, bukkitPlugin.EffectiveDefault("a.inline"))
+	assert.Equal(t, "Nested inline", bukkitPlugin.Permissions["a.nested"].Description)
+	assert.Equal(t, mcmodmeta.BukkitPermissionTrue, bukkitPlugin.EffectiveDefault("a.nested"))
+	assert.Equal(t, mcmodmeta.BukkitPermissionOp, bukkitPlugin.EffectiveDefault("a.inherited"))
+	assert.Equal(t, "Declared at the top level", bukkitPlugin.Permissions["a.use"].Description)
+	assert.Equal(t, mcmodmeta.BukkitPermissionNotOp, bukkitPlugin.EffectiveDefault("b.inline"))
+}
+
+func TestBukkitPermissionDefaults(t *testing.T) {
+	cases := map[string]mcmodmeta.BukkitPermissionDefault{
+		"true":     mcmodmeta.BukkitPermissionTrue,
+		"FALSE":    mcmodmeta.BukkitPermissionFalse,
+		"is_op":    mcmodmeta.BukkitPermissionOp,
+		"is-op":    mcmodmeta.BukkitPermissionOp,
+		"Operator": mcmodmeta.BukkitPermissionOp,
+		"not op":   mcmodmeta.BukkitPermissionNotOp,
+		"not_op":   mcmodmeta.BukkitPermissionNotOp,
+		"NOT_OP":   mcmodmeta.BukkitPermissionNotOp,
+		"not-op":   mcmodmeta.BukkitPermissionNotOp,
+		"'!admin'": mcmodmeta.BukkitPermissionNotOp,
+	}
+	for value, expected := range cases {
+		bukkitPlugin, err := mcmodmeta.NewBukkitPlugin("name: TaterLib\nversion: 0.1.0\ndefault-permission: " + value + "\n")
+		assert.Nil(t, err, value)
+		assert.Equal(t, expected, bukkitPlugin.DefaultPermission, value)
+	}
+
+	_, err := mcmodmeta.NewBukkitPlugin("name: TaterLib\nversion: 0.1.0\ndefault-permission: sometimes\n")
+	assert.NotNil(t, err)
+}
+
+func TestBukkitInvalidLoadOrder(t *testing.T) {
+	_, err := mcmodmeta.NewBukkitPlugin("name: TaterLib\nversion: 0.1.0\nload: SOMETIMES\n")
+	assert.NotNil(t, err)
+
+	_, err = mcmodmeta.NewBukkitPlugin("name: TaterLib\npermissions:\n  a.b:\n    default: maybe\n")
+	assert.NotNil(t, err)
+}
+
+func TestFabricFullSchema(t *testing.T) {
+	fabricString := `{
+  "schemaVersion": 1,
+  "id": "taterlib",
+  "version": "0.1.0",
+  "environment": ["client", "server"],
+  "entrypoints": {
+    "main": ["dev.neuralnexus.taterlib.fabric.FabricTaterLibPlugin"],
+    "client": [{"adapter": "kotlin", "value": "dev.neuralnexus.taterlib.fabric.ClientKt::init"}]
+  },
+  "mixins": [
+    "taterlib.mixins.json",
+    {"config": "taterlib.client.mixins.json", "environment": "client"}
+  ],
+  "provides": ["tater_lib"],
+  "authors": ["p0t4t0sandwich"],
+  "contributors": [
+    "apple",
+    {"name": "banana", "contact": {"homepage": "https://banana.example"}}
+  ],
+  "license": ["MIT", "Apache-2.0"],
+  "icon": {"16": "assets/taterlib/icon16.png", "128": "assets/taterlib/icon128.png", "64": "assets/taterlib/icon64.png"},
+  "custom": {"modmenu": {"badges": ["library"]}, "loom:injected_interfaces": {}}
+}`
+	fabricMod, err := mcmodmeta.NewFabricMod(fabricString)
+
+	assert.Nil(t, err)
+	assert.Equal(t, mcmodmeta.StringList{"client", "server"}, fabricMod.Environment)
+	assert.Equal(t, []mcmodmeta.FabricEntrypoint{{Adapter: "default", Value: "dev.neuralnexus.taterlib.fabric.FabricTaterLibPlugin"}}, fabricMod.EntryPoints["main"])
+	assert.Equal(t, "kotlin", fabricMod.EntryPoints["client"][0].Adapter)
+	assert.Equal(t, []mcmodmeta.FabricMixin{
+		{Config: "taterlib.mixins.json", Environment: "*"},
+		{Config: "taterlib.client.mixins.json", Environment: "client"},
+	}, fabricMod.Mixins)
+	assert.Equal(t, []string{"tater_lib"}, fabricMod.Provides)
+	assert.Equal(t, mcmodmeta.FabricPerson{Name: "apple"}, fabricMod.Contributors[0])
+	assert.Equal(t, "https://banana.example", fabricMod.Contributors[1].(mcmodmeta.FabricPerson).Contact.HomePage)
+	assert.Equal(t, mcmodmeta.StringList{"MIT", "Apache-2.0"}, fabricMod.License)
+	assert.Equal(t, "assets/taterlib/icon64.png", fabricMod.Icon.Best(48))
+	assert.Equal(t, "assets/taterlib/icon128.png", fabricMod.Icon.Best(512))
+	assert.Equal(t, 2, len(fabricMod.Custom))
+	assert.JSONEq(t, `{"badges": ["library"]}`, string(fabricMod.Custom["modmenu"]))
+
+	single, err := mcmodmeta.NewFabricMod(`{"schemaVersion": 1, "id": "taterlib", "version": "0.1.0", "license": "MIT", "icon": "icon.png"}`)
+
+	assert.Nil(t, err)
+	assert.Equal(t, mcmodmeta.StringList{"MIT"}, single.License)
+	assert.Equal(t, "icon.png", single.Icon.Best(64))
+}
+
+func TestFabricSchemaVersion0(t *testing.T) {
+	fabricString := `{
+  "id": "oldmod",
+  "version": "1.0.0",
+  "name": "Old Mod",
+  "side": "universal",
+  "initializers": ["net.example.oldmod.OldMod"],
+  "mixins": {
+    "client": "oldmod.client.json",
+    "common": ["oldmod.common.json"],
+    "server": "oldmod.server.json"
+  },
+  "requires": {"fabric": "*", "minecraft": "1.14"},
+  "conflicts": {"newmod": "*"},
+  "authors": ["Some Author <author@example.com> (https://author.example)", "Someone Else"],
+  "links": "https://oldmod.example",
+  "license": "MIT"
+}`
+	fabricMod, err := mcmodmeta.NewFabricMod(fabricString)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 0, fabricMod.SchemaVersion)
+	assert.Equal(t, "oldmod", fabricMod.ID)
+	assert.Equal(t, mcmodmeta.StringList{"*"}, fabricMod.Environment)
+	assert.Equal(t, []mcmodmeta.FabricEntrypoint{{Adapter: "default", Value: "net.example.oldmod.OldMod"}}, fabricMod.EntryPoints["main"])
+	assert.Equal(t, []mcmodmeta.FabricMixin{
+		{Config: "oldmod.client.json", Environment: "client"},
+		{Config: "oldmod.common.json", Environment: "*"},
+		{Config: "oldmod.server.json", Environment: "server"},
+	}, fabricMod.Mixins)
+	assert.Equal(t, map[string]any{"fabric": "*", "minecraft": "1.14"}, fabricMod.Depends)
+	assert.Equal(t, map[string]any{"newmod": "*"}, fabricMod.Breaks)
+	assert.Equal(t, mcmodmeta.FabricPerson{
+		Name:    "Some Author",
+		Contact: mcmodmeta.FabricContactInformation{Email: "author@example.com", HomePage: "https://author.example"},
+	}, fabricMod.Authors[0])
+	assert.Equal(t, mcmodmeta.FabricPerson{Name: "Someone Else"}, fabricMod.Authors[1])
+	assert.Equal(t, "https://oldmod.example", fabricMod.Contact.HomePage)
+	assert.Equal(t, mcmodmeta.StringList{"MIT"}, fabricMod.License)
+
+	client, err := mcmodmeta.NewFabricMod(`{"id": "oldmod", "version": "1.0.0", "side": "client", "initializer": "net.example.oldmod.OldMod"}`)
+
+	assert.Nil(t, err)
+	assert.Equal(t, mcmodmeta.StringList{"client"}, client.Environment)
+	assert.Equal(t, 1, len(client.EntryPoints["main"]))
+
+	_, err = mcmodmeta.NewFabricMod(`{"schemaVersion": 2, "id": "newmod", "version": "1.0.0"}`)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,93 @@
+package mcmodmeta
+
+import (
+	"slices"
+	"strings"
+)
+
+// RunsOnClient reports whether a mod of this side belongs in a client's mods folder, assuming it does if unknown
+func (side Side) RunsOnClient() bool {
+	return side != SideServer
+}
+
+// RunsOnServer reports whether a mod of this side belongs in a server's mods folder, assuming it does if unknown
+func (side Side) RunsOnServer() bool {
+	return side != SideClient
+}
+
+// Side combines the sides of every mod in the jar. Mods of an unknown side are ignored,
+// and a jar holding both client-only and server-only mods is needed on both sides.
+func (jar *JarMetadata) Side() Side {
+	side := SideUnknown
+	for _, mod := range jar.Mods {
+		switch {
+		case mod.Side == SideUnknown || mod.Side == "":
+			continue
+		case side == SideUnknown:
+			side = mod.Side
+		case side != mod.Side:
+			return SideBoth
+		}
+	}
+	return side
+}
+
+// fabricSide classifies a fabric.mod.json environment, which is client, server or * either alone or in a list
+func fabricSide(environment StringList) Side {
+	if len(environment) == 0 || slices.Contains(environment, "*") {
+		return SideBoth
+	}
+	client, server := slices.Contains(environment, "client"), slices.Contains(environment, "server")
+	switch {
+	case client && server:
+		return SideBoth
+	case client:
+		return SideClient
+	case server:
+		return SideServer
+	}
+	return SideUnknown
+}
+
+// quiltSide classifies a quilt.mod.json minecraft environment, which is client, dedicated_server or *
+func quiltSide(environment string) Side {
+	switch environment {
+	case "client":
+		return SideClient
+	case "dedicated_server":
+		return SideServer
+	}
+	return SideBoth
+}
+
+// forgeSide classifies a Forge or NeoForge mod. clientSideOnly marks a client mod, and a dependency on the game or
+// loader limited to one side marks a mod for that side. Otherwise displayTest decides: IGNORE_SERVER_VERSION is
+// for server-only mods, IGNORE_ALL_VERSION for client-only mods, and MATCH_VERSION, the default, requires the mod
+// on both sides; NONE says nothing.
+func forgeSide(clientSideOnly bool, displayTest string, deps []ModDependency) Side {
+	if clientSideOnly {
+		return SideClient
+	}
+
+	for _, dep := range deps {
+		if dep.ID != "minecraft" && dep.ID != "forge" && dep.ID != "neoforge" {
+			continue
+		}
+		switch strings.ToUpper(dep.Side) {
+		case "CLIENT":
+			return SideClient
+		case "SERVER":
+			return SideServer
+		}
+	}
+
+	switch strings.ToUpper(displayTest) {
+	case "", "MATCH_VERSION":
+		return SideBoth
+	case "IGNORE_SERVER_VERSION":
+		return SideServer
+	case "IGNORE_ALL_VERSION":
+		return SideClient
+	}
+	return SideUnknown
+}
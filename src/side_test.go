@@ -0,0 +1,82 @@
+package mcmodmeta_test
+
+import (
+	mcmodmeta "mc-mod-metadata/src"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFabricEnvironmentList(t *testing.T) {
+	mod, err := mcmodmeta.NewFabricMod(`{"schemaVersion": 1, "id": "taterlib", "version": "1.0.0", "environment": ["client"]}`)
+
+	assert.Nil(t, err)
+	assert.Equal(t, mcmodmeta.StringList{"client"}, mod.Environment)
+
+	jar := mustReadJar(t, writeTestJar(t, map[string]string{
+		"fabric.mod.json": `{"schemaVersion": 1, "id": "taterlib", "version": "1.0.0", "environment": ["client", "server"]}`,
+	}))
+	assert.Equal(t, mcmodmeta.SideBoth, jar.Mods[0].Side)
+}
+
+func TestModSides(t *testing.T) {
+	cases := []struct {
+		name    string
+		entries map[string]string
+		side    mcmodmeta.Side
+	}{
+		{"fabric client", map[string]string{
+			"fabric.mod.json": `{"schemaVersion": 1, "id": "zoomify", "version": "1.0.0", "environment": "client"}`,
+		}, mcmodmeta.SideClient},
+		{"fabric server", map[string]string{
+			"fabric.mod.json": `{"schemaVersion": 1, "id": "ledger", "version": "1.0.0", "environment": "server"}`,
+		}, mcmodmeta.SideServer},
+		{"forge default", map[string]string{
+			"META-INF/mods.toml": "modLoader = \"javafml\"\nloaderVersion = \"[47,)\"\nlicense = \"MIT\"\n[[mods]]\nmodId = \"taterlib\"\n",
+		}, mcmodmeta.SideBoth},
+		{"forge ignore server version", map[string]string{
+			"META-INF/mods.toml": "modLoader = \"javafml\"\nloaderVersion = \"[47,)\"\nlicense = \"MIT\"\n[[mods]]\nmodId = \"spark\"\ndisplayTest = \"IGNORE_SERVER_VERSION\"\n",
+		}, mcmodmeta.SideServer},
+		{"forge client side only", map[string]string{
+			"META-INF/mods.toml": "modLoader = \"javafml\"\nloaderVersion = \"[47,)\"\nlicense = \"MIT\"\nclientSideOnly = true\n[[mods]]\nmodId = \"oculus\"\n",
+		}, mcmodmeta.SideClient},
+		{"forge server dependency", map[string]string{
+			"META-INF/mods.toml": "modLoader = \"javafml\"\nloaderVersion = \"[47,)\"\nlicense = \"MIT\"\n[[mods]]\nmodId = \"spark\"\ndisplayTest = \"IGNORE_ALL_VERSION\"\n" +
+				"[[dependencies.spark]]\nmodId = \"minecraft\"\nmandatory = true\nversionRange = \"[1.20.1,1.21)\"\nside = \"SERVER\"\n",
+		}, mcmodmeta.SideServer},
+		{"forge ignore all version", map[string]string{
+			"META-INF/mods.toml": "modLoader = \"javafml\"\nloaderVersion = \"[47,)\"\nlicense = \"MIT\"\n[[mods]]\nmodId = \"oculus\"\ndisplayTest = \"IGNORE_ALL_VERSION\"\n",
+		}, mcmodmeta.SideClient},
+		{"forge display test none", map[string]string{
+			"META-INF/mods.toml": "modLoader = \"javafml\"\nloaderVersion = \"[47,)\"\nlicense = \"MIT\"\n[[mods]]\nmodId = \"taterlib\"\ndisplayTest = \"NONE\"\n",
+		}, mcmodmeta.SideUnknown},
+		{"neoforge client dependency", map[string]string{
+			"META-INF/neoforge.mods.toml": "modLoader = \"javafml\"\nloaderVersion = \"[1,)\"\nlicense = \"MIT\"\n[[mods]]\nmodId = \"sodium\"\n" +
+				"[[dependencies.sodium]]\nmodId = \"neoforge\"\ntype = \"required\"\nversionRange = \"[20.4,)\"\nside = \"CLIENT\"\n",
+		}, mcmodmeta.SideClient},
+		{"bukkit", map[string]string{
+			"plugin.yml": "name: TaterLib\nversion: 1.0.0\nmain: dev.neuralnexus.taterlib.Bukkit\n",
+		}, mcmodmeta.SideServer},
+	}
+
+	for _, c := range cases {
+		jar := mustReadJar(t, writeTestJar(t, c.entries))
+		assert.Equal(t, 1, len(jar.Mods), c.name)
+		assert.Equal(t, c.side, jar.Mods[0].Side, c.name)
+		assert.Equal(t, c.side, jar.Side(), c.name)
+	}
+}
+
+func TestJarSide(t *testing.T) {
+	jar := mustReadJar(t, writeTestJar(t, map[string]string{
+		"fabric.mod.json": `{"schemaVersion": 1, "id": "taterlib", "version": "1.0.0", "environment": "client"}`,
+		"plugin.yml":      "name: TaterLib\nversion: 1.0.0\nmain: dev.neuralnexus.taterlib.Bukkit\n",
+		"mcmod.info":      `[{"modid": "taterlib"}]`,
+	}))
+
+	assert.Equal(t, mcmodmeta.SideBoth, jar.Side())
+	assert.True(t, mcmodmeta.SideUnknown.RunsOnClient())
+	assert.True(t, mcmodmeta.SideUnknown.RunsOnServer())
+	assert.False(t, mcmodmeta.SideClient.RunsOnServer())
+	assert.False(t, mcmodmeta.SideServer.RunsOnClient())
+}